@@ -0,0 +1,210 @@
+package conekta
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, without contacting the service, while the client
+// circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State of the client circuit breaker
+type BreakerState int
+
+const (
+	// Requests are dispatched normally
+	BreakerClosed BreakerState = iota
+
+	// Requests fail immediately with 'ErrCircuitOpen'
+	BreakerOpen
+
+	// A limited number of requests are dispatched to probe the service recovery
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Circuit breaker configuration. Network errors and server side errors (5xx)
+// are considered failures, other API errors are not
+type CircuitBreaker struct {
+	// Fraction of failed requests, between 0 and 1, required to open the circuit
+	ErrorRate float64
+
+	// Minimum number of requests in the window before the error rate is evaluated
+	MinRequests uint
+
+	// Length of the window used to calculate the error rate, in seconds
+	Window uint
+
+	// Time to keep the circuit open before probing the service, in seconds
+	Cooldown uint
+
+	// Number of successful probes required to close the circuit again
+	Probes uint
+}
+
+// Outcome of a request for the circuit breaker
+type breakerResult int
+
+const (
+	resultSuccess breakerResult = iota
+	resultFailure
+	resultIgnored
+)
+
+// Circuit breaker implementation, safe for concurrent use
+type breaker struct {
+	conf     CircuitBreaker
+	mu       sync.Mutex
+	state    BreakerState
+	since    time.Time
+	total    uint
+	failures uint
+	inFlight uint
+	probes   uint
+
+	// Incremented on every transition, used to discard the outcome of
+	// requests allowed on a previous state
+	generation uint64
+}
+
+// Return a new circuit breaker for the provided configuration, 'nil' if
+// not required
+func newBreaker(conf *CircuitBreaker) *breaker {
+	if conf == nil || conf.ErrorRate <= 0 {
+		return nil
+	}
+	b := &breaker{
+		conf:  *conf,
+		state: BreakerClosed,
+		since: time.Now(),
+	}
+	if b.conf.MinRequests == 0 {
+		b.conf.MinRequests = 10
+	}
+	if b.conf.Window == 0 {
+		b.conf.Window = 60
+	}
+	if b.conf.Cooldown == 0 {
+		b.conf.Cooldown = 30
+	}
+	if b.conf.Probes == 0 {
+		b.conf.Probes = 1
+	}
+	return b
+}
+
+// Move to a new state, must be called with the lock held
+func (b *breaker) transition(state BreakerState, now time.Time) {
+	b.state = state
+	b.since = now
+	b.total = 0
+	b.failures = 0
+	b.inFlight = 0
+	b.probes = 0
+	b.generation++
+}
+
+// Return the current state of the breaker
+func (b *breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh(time.Now())
+	return b.state
+}
+
+// Apply time based transitions, must be called with the lock held
+func (b *breaker) refresh(now time.Time) {
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.since) >= time.Duration(b.conf.Cooldown)*time.Second {
+			b.transition(BreakerHalfOpen, now)
+		}
+	case BreakerClosed:
+		if now.Sub(b.since) >= time.Duration(b.conf.Window)*time.Second {
+			b.transition(BreakerClosed, now)
+		}
+	}
+}
+
+// Verify a new request is allowed, returning the generation the request was
+// admitted on, to be provided when recording its outcome
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh(time.Now())
+	switch b.state {
+	case BreakerOpen:
+		return 0, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.inFlight+b.probes >= b.conf.Probes {
+			return 0, ErrCircuitOpen
+		}
+		b.inFlight++
+	}
+	return b.generation, nil
+}
+
+// Register the outcome of an allowed request. Outcomes of requests admitted
+// before the last transition are discarded
+func (b *breaker) record(generation uint64, res breakerResult) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if generation != b.generation {
+		return
+	}
+	if b.state == BreakerHalfOpen {
+		if b.inFlight > 0 {
+			b.inFlight--
+		}
+		switch res {
+		case resultFailure:
+			b.transition(BreakerOpen, now)
+		case resultSuccess:
+			b.probes++
+			if b.probes >= b.conf.Probes {
+				b.transition(BreakerClosed, now)
+			}
+		}
+		return
+	}
+
+	b.refresh(now)
+	if b.state != BreakerClosed || res == resultIgnored {
+		return
+	}
+	b.total++
+	if res == resultFailure {
+		b.failures++
+	}
+	if b.total >= b.conf.MinRequests && float64(b.failures)/float64(b.total) >= b.conf.ErrorRate {
+		b.transition(BreakerOpen, now)
+	}
+}
+
+// Classify the outcome of a request for the circuit breaker
+func classifyResult(status int, err error) breakerResult {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return resultIgnored
+	case status >= 500:
+		return resultFailure
+	case err != nil && status == 0:
+		return resultFailure
+	}
+	return resultSuccess
+}
@@ -0,0 +1,85 @@
+package conekta
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newBreaker(&CircuitBreaker{
+		ErrorRate:   0.5,
+		MinRequests: 4,
+		Probes:      2,
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		for _, status := range []int{http.StatusOK, http.StatusBadRequest, http.StatusBadGateway} {
+			gen, err := b.allow()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.record(gen, classifyResult(status, nil))
+		}
+		if b.State() != BreakerClosed {
+			t.Fatal("circuit opened before reaching the minimum number of requests")
+		}
+		gen, _ := b.allow()
+		b.record(gen, classifyResult(0, errors.New("connection refused")))
+		if b.State() != BreakerOpen {
+			t.Fatal("failed to open circuit")
+		}
+		if _, err := b.allow(); err != ErrCircuitOpen {
+			t.Error("open circuit should fail fast")
+		}
+	})
+
+	t.Run("HalfOpen", func(t *testing.T) {
+		b.since = time.Now().Add(-time.Minute)
+		if b.State() != BreakerHalfOpen {
+			t.Fatal("failed to half-open circuit")
+		}
+		gen1, err1 := b.allow()
+		gen2, err2 := b.allow()
+		if err1 != nil || err2 != nil {
+			t.Fatal("probes should be allowed")
+		}
+		if _, err := b.allow(); err != ErrCircuitOpen {
+			t.Error("failed to limit probes")
+		}
+		b.record(gen1, resultSuccess)
+		b.record(gen2, resultSuccess)
+		if b.State() != BreakerClosed {
+			t.Error("failed to close circuit")
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		b := newBreaker(&CircuitBreaker{ErrorRate: 0.5, MinRequests: 1})
+		late, _ := b.allow()
+		gen, _ := b.allow()
+		b.record(gen, resultFailure)
+		if b.State() != BreakerOpen {
+			t.Fatal("failed to open circuit")
+		}
+		b.since = time.Now().Add(-time.Minute)
+		if b.State() != BreakerHalfOpen {
+			t.Fatal("failed to half-open circuit")
+		}
+
+		// A request admitted while closed must not count as a probe
+		b.record(late, resultSuccess)
+		if b.State() != BreakerHalfOpen {
+			t.Error("stale result closed the circuit")
+		}
+		probe, err := b.allow()
+		if err != nil {
+			t.Fatal("probe should be allowed")
+		}
+		b.record(probe, resultSuccess)
+		if b.State() != BreakerClosed {
+			t.Error("failed to close circuit")
+		}
+	})
+}
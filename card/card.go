@@ -0,0 +1,186 @@
+// Package card provides utilities to validate and handle payment card details
+// locally: Luhn checksum, brand detection, expiry normalization and
+// verification, and PAN masking for safe logging.
+package card
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Card brands, using the names reported by the service
+const (
+	Visa            = "visa"
+	Mastercard      = "mastercard"
+	AmericanExpress = "american_express"
+	Carnet          = "carnet"
+)
+
+// Issuer identification number range assigned to a brand, bounds are
+// inclusive and compared using the same number of leading digits
+type iinRange struct {
+	from, to string
+	brand    string
+	lengths  []int
+}
+
+// Known IIN ranges, more specific ranges must be listed first
+var iinRanges = []iinRange{
+	{"286900", "286999", Carnet, []int{16}},
+	{"502275", "502275", Carnet, []int{16}},
+	{"506199", "506499", Carnet, []int{16}},
+	{"639388", "639388", Carnet, []int{16}},
+	{"639484", "639484", Carnet, []int{16}},
+	{"639559", "639559", Carnet, []int{16}},
+	{"34", "34", AmericanExpress, []int{15}},
+	{"37", "37", AmericanExpress, []int{15}},
+	{"51", "55", Mastercard, []int{16}},
+	{"2221", "2720", Mastercard, []int{16}},
+	{"4", "4", Visa, []int{13, 16, 19}},
+}
+
+// Normalize removes spaces and dashes from a card number
+func Normalize(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// Luhn verifies the checksum digit of a card number
+func Luhn(number string) bool {
+	number = Normalize(number)
+	if len(number) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Brand returns the brand of a card number based on its issuer identification
+// number, empty if unknown
+func Brand(number string) string {
+	if r := lookup(Normalize(number)); r != nil {
+		return r.brand
+	}
+	return ""
+}
+
+// Return the IIN range matching a card number, if any
+func lookup(number string) *iinRange {
+	for i, r := range iinRanges {
+		n := len(r.from)
+		if len(number) < n {
+			continue
+		}
+		if prefix := number[:n]; prefix >= r.from && prefix <= r.to {
+			return &iinRanges[i]
+		}
+	}
+	return nil
+}
+
+// ValidateNumber verifies a card number belongs to a known brand, has a valid
+// length for it and a valid checksum
+func ValidateNumber(number string) error {
+	number = Normalize(number)
+	if number == "" {
+		return errors.New("card number is required")
+	}
+	if !Luhn(number) {
+		return errors.New("invalid card number")
+	}
+	r := lookup(number)
+	if r == nil {
+		return errors.New("unsupported card brand")
+	}
+	for _, l := range r.lengths {
+		if len(number) == l {
+			return nil
+		}
+	}
+	return errors.New("invalid card number length")
+}
+
+// NormalizeExpiry parses an expiration month and year, accepting both two
+// and four digit years
+func NormalizeExpiry(month, year string) (int, int, error) {
+	m, err := strconv.Atoi(strings.TrimSpace(month))
+	if err != nil || m < 1 || m > 12 {
+		return 0, 0, errors.New("invalid expiration month")
+	}
+	year = strings.TrimSpace(year)
+	y, err := strconv.Atoi(year)
+	if err != nil || y < 0 {
+		return 0, 0, errors.New("invalid expiration year")
+	}
+	switch len(year) {
+	case 2:
+		y += 2000
+	case 4:
+	default:
+		return 0, 0, errors.New("invalid expiration year")
+	}
+	return m, y, nil
+}
+
+// Expired reports whether a card is expired at the provided time. Cards are
+// valid until the end of their expiration month
+func Expired(month, year string, now time.Time) (bool, error) {
+	m, y, err := NormalizeExpiry(month, year)
+	if err != nil {
+		return false, err
+	}
+	end := time.Date(y, time.Month(m)+1, 1, 0, 0, 0, 0, now.Location())
+	return !now.Before(end), nil
+}
+
+// Validate verifies a card number and its expiration date at the provided time
+func Validate(number, month, year string, now time.Time) error {
+	if err := ValidateNumber(number); err != nil {
+		return err
+	}
+	expired, err := Expired(month, year, now)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return errors.New("card is expired")
+	}
+	return nil
+}
+
+// Mask hides the digits of a card number, keeping only the first 6 and last 4
+// digits, so it can be safely logged. Short values are masked entirely except
+// for the last 4 digits
+func Mask(number string) string {
+	number = Normalize(number)
+	n := len(number)
+	switch {
+	case n == 0:
+		return ""
+	case n >= 13:
+		return number[:6] + strings.Repeat("*", n-10) + number[n-4:]
+	case n > 4:
+		return strings.Repeat("*", n-4) + number[n-4:]
+	}
+	return strings.Repeat("*", n)
+}
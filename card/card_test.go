@@ -0,0 +1,80 @@
+package card
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCard(t *testing.T) {
+	t.Run("Luhn", func(t *testing.T) {
+		for _, n := range []string{"4242424242424242", "4242 4242 4242 4242", "378282246310005", "79927398713"} {
+			if !Luhn(n) {
+				t.Errorf("valid number rejected: %s", n)
+			}
+		}
+		for _, n := range []string{"4242424242424241", "", "4", "4242a24242424242"} {
+			if Luhn(n) {
+				t.Errorf("invalid number accepted: %s", n)
+			}
+		}
+	})
+
+	t.Run("Brand", func(t *testing.T) {
+		brands := map[string]string{
+			"4242424242424242": Visa,
+			"5555555555554444": Mastercard,
+			"2223003122003222": Mastercard,
+			"378282246310005":  AmericanExpress,
+			"5062541600005232": Carnet,
+			"6011111111111117": "",
+		}
+		for n, b := range brands {
+			if Brand(n) != b {
+				t.Errorf("%s: expected brand '%s', got '%s'", n, b, Brand(n))
+			}
+		}
+		if ValidateNumber("6011111111111117") == nil {
+			t.Error("unsupported brand accepted")
+		}
+		if ValidateNumber("37828224631000") == nil {
+			t.Error("invalid length accepted")
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+		m, y, err := NormalizeExpiry("09", "19")
+		if err != nil || m != 9 || y != 2019 {
+			t.Error("failed to normalize two digit year")
+		}
+		if _, _, err := NormalizeExpiry("13", "2030"); err == nil {
+			t.Error("invalid month accepted")
+		}
+		if _, _, err := NormalizeExpiry("12", "230"); err == nil {
+			t.Error("invalid year accepted")
+		}
+		if expired, _ := Expired("06", "2024", now); expired {
+			t.Error("card should be valid until the end of its expiration month")
+		}
+		if expired, _ := Expired("05", "24", now); !expired {
+			t.Error("failed to detect expired card")
+		}
+		if Validate("4242424242424242", "09", "19", now) == nil {
+			t.Error("expired card accepted")
+		}
+	})
+
+	t.Run("Mask", func(t *testing.T) {
+		masks := map[string]string{
+			"4242 4242 4242 4242": "424242******4242",
+			"378282246310005":     "378282*****0005",
+			"123456":              "**3456",
+			"123":                 "***",
+		}
+		for n, m := range masks {
+			if Mask(n) != m {
+				t.Errorf("%s: expected '%s', got '%s'", n, m, Mask(n))
+			}
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package conekta
+
+import (
+	"time"
+
+	"github.com/fairbank-io/conekta/card"
+)
+
+// Validate verifies locally the card number checksum, brand and expiration
+// date. Cards referencing a token or payment source are not verified
+func (c *Card) Validate() error {
+	if c.Number == "" && (c.TokenID != "" || c.PaymentSourceID != "") {
+		return nil
+	}
+	return card.Validate(c.Number, c.ExpMonth, c.ExpYear, time.Now())
+}
+
+// DetectBrand returns the card brand based on its number, falls back to the
+// brand reported by the service if the number is not available
+func (c *Card) DetectBrand() string {
+	if b := card.Brand(c.Number); b != "" {
+		return b
+	}
+	return c.Brand
+}
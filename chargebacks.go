@@ -0,0 +1,44 @@
+package conekta
+
+import (
+	"errors"
+	"net/http"
+	"path"
+)
+
+// Defines the public interface required to access available 'chargebacks' methods
+type ChargebacksAPI interface {
+	// Retrieves an existing chargeback
+	// https://developers.conekta.com/api?language=bash#chargeback
+	Get(chargebackID string) (*Chargeback, error)
+
+	// Lists existing chargebacks. If no options are provided all chargebacks
+	// are returned. Use the 'status' filter to get only open cases
+	// https://developers.conekta.com/api?language=bash#chargeback
+	List(opts *ListOptions) ([]Chargeback, error)
+
+	// Submits evidence to contest a chargeback. Evidence must be submitted
+	// before the chargeback 'EvidenceDueBy' date
+	// https://developers.conekta.com/api?language=bash#chargeback
+	SubmitEvidence(chargebackID string, evidence *ChargebackEvidence) (*Chargeback, error)
+}
+
+type chargebacksClient struct {
+	c *Client
+}
+
+func (cc *chargebacksClient) Get(chargebackID string) (*Chargeback, error) {
+	return send[Chargeback](cc.c, "chargebacks.get", http.MethodGet, baseUrl+path.Join("chargebacks", chargebackID), nil)
+}
+
+func (cc *chargebacksClient) List(opts *ListOptions) ([]Chargeback, error) {
+	return listAll[Chargeback](cc.c, "chargebacks.list", baseUrl+"chargebacks", opts)
+}
+
+func (cc *chargebacksClient) SubmitEvidence(chargebackID string, evidence *ChargebackEvidence) (*Chargeback, error) {
+	if evidence == nil {
+		return nil, errors.New("chargeback evidence is required")
+	}
+	return send[Chargeback](cc.c, "chargebacks.submit_evidence", http.MethodPost,
+		baseUrl+path.Join("chargebacks", chargebackID, "evidence"), evidence)
+}
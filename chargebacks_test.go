@@ -0,0 +1,81 @@
+package conekta
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestEventChargeback(t *testing.T) {
+	t.Run("Chargeback", func(t *testing.T) {
+		e := &Event{
+			Type: "charge.chargeback.created",
+			Data: EventData{Object: []byte(`{"id": "cbk_1", "object": "chargeback", "status": "pending",
+				"amount": 1500, "charge_id": "ch_1", "evidence_due_by": 1700000000}`)},
+		}
+		cb, err := e.Chargeback()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cb.ID != "cbk_1" || cb.Status != ChargebackPending || cb.Amount != 1500 || cb.ChargeID != "ch_1" {
+			t.Errorf("invalid chargeback: %+v", cb)
+		}
+	})
+
+	t.Run("Charge", func(t *testing.T) {
+		e := &Event{
+			Type: "charge.chargeback.lost",
+			Data: EventData{Object: []byte(`{"id": "ch_2", "object": "charge", "status": "charged_back",
+				"chargeback": {"id": "cbk_2", "status": "lost", "amount": 900}}`)},
+		}
+		cb, err := e.Chargeback()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cb.ID != "cbk_2" || cb.Status != ChargebackLost || cb.ChargeID != "ch_2" {
+			t.Errorf("invalid chargeback: %+v", cb)
+		}
+
+		e.Data.Object = []byte(`{"id": "ch_3", "object": "charge"}`)
+		if _, err := e.Chargeback(); err == nil {
+			t.Error("failed to detect charge without chargeback")
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		e := &Event{Type: "order.paid", Data: EventData{Object: []byte(`{"id": "ord_1"}`)}}
+		if _, err := e.Chargeback(); err == nil {
+			t.Error("failed to detect non chargeback event")
+		}
+	})
+}
+
+func TestChargebacks(t *testing.T) {
+	t.Run("SubmitEvidence", func(t *testing.T) {
+		rt := &recordingTransport{reply: `{"id": "cbk_1", "status": "under_review",
+			"evidence": {"customer_name": "Jane Doe"}}`}
+		client := recordingClient(t, rt)
+		cb, err := client.Chargebacks.SubmitEvidence("cbk_1", &ChargebackEvidence{
+			CustomerName: "Jane Doe",
+			DocumentURLs: []string{"https://example.com/receipt.pdf"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cb.Status != ChargebackUnderReview || cb.Evidence == nil || cb.Evidence.CustomerName != "Jane Doe" {
+			t.Errorf("invalid chargeback: %+v", cb)
+		}
+		req := rt.requests[0]
+		if req.Method != http.MethodPost || req.URL.Path != "/chargebacks/cbk_1/evidence" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		var body ChargebackEvidence
+		if err := json.Unmarshal([]byte(rt.bodies[0]), &body); err != nil || len(body.DocumentURLs) != 1 {
+			t.Errorf("unexpected body: %s", rt.bodies[0])
+		}
+
+		if _, err := client.Chargebacks.SubmitEvidence("cbk_1", nil); err == nil || len(rt.requests) != 1 {
+			t.Error("failed to detect missing evidence")
+		}
+	})
+}
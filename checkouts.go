@@ -0,0 +1,74 @@
+package conekta
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+)
+
+// Defines the public interface required to access available 'checkouts' methods
+type CheckoutsAPI interface {
+	// Creates a new checkout, for example a payment link
+	// https://developers.conekta.com/api?language=bash#create-checkout
+	Create(checkout *Checkout) error
+
+	// Retrieves an existing checkout
+	// https://developers.conekta.com/api?language=bash#checkout
+	Get(checkoutID string) (*Checkout, error)
+
+	// Lists existing checkouts. If no options are provided all checkouts are returned
+	// https://developers.conekta.com/api?language=bash#checkout
+	List(opts *ListOptions) ([]Checkout, error)
+
+	// Cancels a checkout, it can no longer be paid
+	// https://developers.conekta.com/api?language=bash#cancel-checkout
+	Cancel(checkoutID string) (*Checkout, error)
+
+	// Sends the checkout link to the provided email address
+	// https://developers.conekta.com/api?language=bash#send-checkout-email
+	SendEmail(checkoutID, email string) (*Checkout, error)
+
+	// Sends the checkout link to the provided phone number
+	// https://developers.conekta.com/api?language=bash#send-checkout-sms
+	SendSMS(checkoutID, phone string) (*Checkout, error)
+}
+
+type checkoutsClient struct {
+	c *Client
+}
+
+func (cc *checkoutsClient) Create(checkout *Checkout) error {
+	b, err := cc.c.request(&requestOptions{
+		op:       "checkouts.create",
+		endpoint: baseUrl + "checkouts",
+		method:   http.MethodPost,
+		data:     checkout,
+	})
+	if err != nil {
+		return err
+	}
+	json.Unmarshal(b, checkout)
+	return nil
+}
+
+func (cc *checkoutsClient) Get(checkoutID string) (*Checkout, error) {
+	return send[Checkout](cc.c, "checkouts.get", http.MethodGet, baseUrl+path.Join("checkouts", checkoutID), nil)
+}
+
+func (cc *checkoutsClient) List(opts *ListOptions) ([]Checkout, error) {
+	return listAll[Checkout](cc.c, "checkouts.list", baseUrl+"checkouts", opts)
+}
+
+func (cc *checkoutsClient) Cancel(checkoutID string) (*Checkout, error) {
+	return send[Checkout](cc.c, "checkouts.cancel", http.MethodPut, baseUrl+path.Join("checkouts", checkoutID, "cancel"), nil)
+}
+
+func (cc *checkoutsClient) SendEmail(checkoutID, email string) (*Checkout, error) {
+	return send[Checkout](cc.c, "checkouts.send_email", http.MethodPost, baseUrl+path.Join("checkouts", checkoutID, "email"),
+		map[string]string{"email": email})
+}
+
+func (cc *checkoutsClient) SendSMS(checkoutID, phone string) (*Checkout, error) {
+	return send[Checkout](cc.c, "checkouts.send_sms", http.MethodPost, baseUrl+path.Join("checkouts", checkoutID, "sms"),
+		map[string]string{"phonenumber": phone})
+}
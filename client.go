@@ -2,12 +2,12 @@ package conekta
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"time"
 )
@@ -32,6 +32,31 @@ type Options struct {
 
 	// User agent value to report to the service
 	UserAgent string
+
+	// Public API key, required only to create card tokens
+	PublicKey string
+
+	// Functions to execute around every request, useful for logging and auditing
+	Hooks *Hooks
+
+	// Maximum request rate allowed across all resources, not limited by default
+	RateLimit *RateLimit
+
+	// Maximum request rate allowed per resource, keyed by resource name, for
+	// example 'orders', 'customers' or 'plans'. Applied in addition to 'RateLimit'
+	ResourceRateLimits map[string]*RateLimit
+
+	// Circuit breaker to fail fast while the service is degraded, disabled by default
+	CircuitBreaker *CircuitBreaker
+
+	// Network transport settings: proxy, TLS and per phase timeouts
+	Transport *TransportOptions
+
+	// Address subscriptions by ID on pause, resume and cancel operations using
+	// the 'customers/:id/subscriptions/:subscription_id' routes, required by
+	// customers with multiple subscriptions. By default the single subscription
+	// routes are used, providing the subscription ID on the request body
+	MultipleSubscriptions bool
 }
 
 // Main service handler
@@ -45,17 +70,39 @@ type Client struct {
 	// Methods related to 'plans' management
 	Plans PlansAPI
 
+	// Methods related to 'subscriptions' management
+	Subscriptions SubscriptionsAPI
+
+	// Methods related to 'checkouts' management
+	Checkouts CheckoutsAPI
+
+	// Methods related to 'chargebacks' management
+	Chargebacks ChargebacksAPI
+
+	// Methods related to 'tokens' management
+	Tokens TokensAPI
+
 	c          *http.Client
 	key        string
+	publicKey  string
 	apiVersion string
 	userAgent  string
+	hooks      *Hooks
+	limiter    *rateLimiter
+	breaker    *breaker
+	locks      *keyedMutex
+	ctx        context.Context
+
+	multipleSubscriptions bool
 }
 
 // Network request options
 type requestOptions struct {
+	op       string
 	method   string
 	endpoint string
 	data     interface{}
+	public   bool
 }
 
 // Return sane default configuration values
@@ -69,54 +116,222 @@ func defaultOptions() *Options {
 	}
 }
 
+// Return a copy of the provided options using default values for any setting
+// not provided
+func (o *Options) withDefaults() *Options {
+	def := defaultOptions()
+	if o == nil {
+		return def
+	}
+	res := *o
+	if res.Timeout == 0 {
+		res.Timeout = def.Timeout
+	}
+	if res.KeepAlive == 0 {
+		res.KeepAlive = def.KeepAlive
+	}
+	if res.MaxConnections == 0 {
+		res.MaxConnections = def.MaxConnections
+	}
+	if res.APIVersion == "" {
+		res.APIVersion = def.APIVersion
+	}
+	return &res
+}
+
 // NewClient will construct a usable service handler using the provided API key and
-// configuration options, if 'nil' options are provided default sane values will
-// be used
+// configuration options, default sane values will be used for any setting not
+// provided
 func NewClient(key string, options *Options) (*Client, error) {
 	if key == "" {
 		return nil, errors.New("API key is required")
 	}
 
-	// If no options are provided, use default sane values
-	if options == nil {
-		options = defaultOptions()
-	}
+	// Use default sane values for any setting not provided
+	options = options.withDefaults()
 
 	// Configure base HTTP transport
-	t := &http.Transport{
-		MaxIdleConns:        int(options.MaxConnections),
-		MaxIdleConnsPerHost: int(options.MaxConnections),
-		DialContext: (&net.Dialer{
-			Timeout:   time.Duration(options.Timeout) * time.Second,
-			KeepAlive: time.Duration(options.KeepAlive) * time.Second,
-			DualStack: true,
-		}).DialContext,
+	t, err := newTransport(options)
+	if err != nil {
+		return nil, err
 	}
 
 	// Setup main client
 	client := &Client{
 		key:        key,
+		publicKey:  options.PublicKey,
 		apiVersion: options.APIVersion,
 		userAgent:  options.UserAgent,
+		hooks:      options.Hooks,
+		limiter:    newRateLimiter(options.RateLimit, options.ResourceRateLimits),
+		breaker:    newBreaker(options.CircuitBreaker),
+		locks:      &keyedMutex{},
 		c: &http.Client{
 			Transport: t,
 			Timeout:   time.Duration(options.Timeout) * time.Second,
 		},
+		multipleSubscriptions: options.MultipleSubscriptions,
 	}
-	client.Orders = &ordersClient{c: client}
-	client.Customers = &customersClient{c: client}
-	client.Plans = &plansClient{c: client}
+	client.setup()
 	return client, nil
 }
 
-// Dispatch a network request to the service
+// WithContext returns a copy of the client with all its requests bound to the
+// provided context, to support cancellation and trace propagation. The copy
+// shares the underlying network resources with the original client
+func (i *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c := *i
+	c.ctx = ctx
+	c.setup()
+	return &c
+}
+
+// Initialize resource handlers
+func (i *Client) setup() {
+	i.Orders = &ordersClient{c: i}
+	i.Customers = &customersClient{c: i}
+	i.Plans = &plansClient{c: i}
+	i.Subscriptions = &subscriptionsClient{c: i}
+	i.Checkouts = &checkoutsClient{c: i}
+	i.Chargebacks = &chargebacksClient{c: i}
+	i.Tokens = &tokensClient{c: i}
+}
+
+// Return the context requests should be bound to
+func (i *Client) context() context.Context {
+	if i.ctx != nil {
+		return i.ctx
+	}
+	return context.Background()
+}
+
+// CircuitState returns the current state of the client circuit breaker, useful
+// for health checks. Always closed if no circuit breaker is configured
+func (i *Client) CircuitState() BreakerState {
+	if i.breaker == nil {
+		return BreakerClosed
+	}
+	return i.breaker.State()
+}
+
+// Dispatch a network request to the service, executing the registered hooks
+// and applying the configured rate limits and circuit breaker
 func (i *Client) request(r *requestOptions) ([]byte, error) {
+	if v, ok := r.data.(validator); ok {
+		if err := v.validate(); err != nil {
+			return nil, err
+		}
+	}
+	var generation uint64
+	if i.breaker != nil {
+		var err error
+		if generation, err = i.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+	if i.limiter != nil {
+		if err := i.limiter.Wait(i.context(), r.op); err != nil {
+			if i.breaker != nil {
+				i.breaker.record(generation, resultIgnored)
+			}
+			return nil, err
+		}
+	}
+	if i.hooks == nil {
+		status, body, err := i.do(i.context(), r)
+		i.report(r.op, generation, status, err)
+		return body, err
+	}
+
+	info := RequestInfo{
+		Context:    i.context(),
+		Operation:  r.op,
+		Method:     r.method,
+		Endpoint:   redactURL(r.endpoint),
+		APIVersion: i.apiVersion,
+	}
+	if data, err := json.Marshal(r.data); err == nil && r.data != nil {
+		info.Body = redactJSON(data)
+	}
+	if i.hooks.BeforeRequest != nil {
+		i.hooks.BeforeRequest(&info)
+		if info.Context == nil {
+			info.Context = i.context()
+		}
+	}
+
+	start := time.Now()
+	status, body, err := i.do(info.Context, r)
+	i.report(r.op, generation, status, err)
+	if i.hooks.AfterRequest != nil {
+		res := &ResponseInfo{
+			RequestInfo: info,
+			Status:      status,
+			Latency:     time.Since(start),
+			Err:         err,
+		}
+		if e, ok := err.(*APIError); ok {
+			res.LogID = e.LogID
+		}
+		if body != nil {
+			obj := struct {
+				ID string `json:"id"`
+			}{}
+			json.Unmarshal(body, &obj)
+			res.ResourceID = obj.ID
+		}
+		i.hooks.AfterRequest(res)
+	}
+	return body, err
+}
+
+// Dispatch a request and decode the resource returned
+func send[T any](c *Client, op, method, endpoint string, data interface{}) (*T, error) {
+	b, err := c.request(&requestOptions{
+		op:       op,
+		endpoint: endpoint,
+		method:   method,
+		data:     data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := new(T)
+	json.Unmarshal(b, res)
+	return res, nil
+}
+
+// Report the outcome of an operation to the rate limiter and circuit breaker,
+// 'generation' is the circuit breaker generation the request was admitted on
+func (i *Client) report(op string, generation uint64, status int, err error) {
+	if i.limiter != nil {
+		i.limiter.observe(op, status)
+	}
+	if i.breaker != nil {
+		i.breaker.record(generation, classifyResult(status, err))
+	}
+}
+
+// Execute a network request, returning the HTTP status code received along with
+// the response contents
+func (i *Client) do(ctx context.Context, r *requestOptions) (int, []byte, error) {
 	// Build request with headers and credentials
-	data, _ := json.Marshal(r.data)
-	req, _ := http.NewRequest(r.method, r.endpoint, bytes.NewReader(data))
+	var payload io.Reader
+	if r.data != nil {
+		data, _ := json.Marshal(r.data)
+		payload = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequestWithContext(ctx, r.method, r.endpoint, payload)
 	req.Header.Add("Accept", fmt.Sprintf("application/vnd.conekta-%s+json", i.apiVersion))
 	req.Header.Add("Content-Type", "application/json")
-	req.SetBasicAuth(i.key, "")
+	if r.public {
+		req.SetBasicAuth(i.publicKey, "")
+	} else {
+		req.SetBasicAuth(i.key, "")
+	}
 	if i.userAgent != "" {
 		req.Header.Add("User-Agent", i.userAgent)
 	}
@@ -131,7 +346,7 @@ func (i *Client) request(r *requestOptions) ([]byte, error) {
 
 	// Network level errors
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
 	// Get response contents
@@ -141,7 +356,7 @@ func (i *Client) request(r *requestOptions) ([]byte, error) {
 	if res.StatusCode != 200 {
 		e := &APIError{}
 		json.Unmarshal(body, e)
-		return nil, e
+		return res.StatusCode, nil, e
 	}
-	return body, nil
+	return res.StatusCode, body, nil
 }
@@ -3,6 +3,7 @@ package conekta
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestConektaClient(t *testing.T) {
@@ -46,6 +47,35 @@ func TestConektaClient(t *testing.T) {
 			}
 		})
 
+		t.Run("Get", func(t *testing.T) {
+			plan, err := client.Plans.Get(testPlan.ID)
+			if err != nil {
+				t.Error(err)
+			}
+			if plan != nil && plan.Amount != 7500 {
+				t.Error("failed to retrieve updated plan")
+			}
+		})
+
+		t.Run("List", func(t *testing.T) {
+			_, err := client.Plans.List(&ListOptions{Limit: 5})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+
+		t.Run("Sync", func(t *testing.T) {
+			desired := *testPlan
+			desired.Amount = 9000
+			actions, err := SyncPlans(client.Plans, []Plan{desired}, &PlanSyncOptions{DryRun: true})
+			if err != nil {
+				t.Error(err)
+			}
+			if len(actions) != 1 || actions[0].Action != PlanSyncUpdate {
+				t.Error("failed to detect plan changes")
+			}
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			err := client.Plans.Delete(testPlan.ID)
 			if err != nil {
@@ -54,6 +84,68 @@ func TestConektaClient(t *testing.T) {
 		})
 	})
 
+	t.Run("Checkouts", func(t *testing.T) {
+		testCheckout := &Checkout{
+			Name:                  "test-payment-link",
+			Type:                  CheckoutPaymentLink,
+			AllowedPaymentMethods: []string{PaymentMethodCash, PaymentMethodCard},
+			ExpiresAt:             uint32(time.Now().Add(48 * time.Hour).Unix()),
+			OrderTemplate: &CheckoutOrderTemplate{
+				Currency: "MXN",
+				LineItems: []LineItem{
+					{
+						Name:      "test digital item",
+						Quantity:  1,
+						UnitPrice: 5000,
+					},
+				},
+			},
+		}
+
+		t.Run("Create", func(t *testing.T) {
+			err := client.Checkouts.Create(testCheckout)
+			if err != nil {
+				t.Error(err.(*APIError).Details[0].DebugMessage)
+			}
+			if testCheckout.URL == "" {
+				t.Error("failed to retrieve checkout URL")
+			}
+		})
+
+		t.Run("Get", func(t *testing.T) {
+			_, err := client.Checkouts.Get(testCheckout.ID)
+			if err != nil {
+				t.Error(err.(*APIError).Details[0].DebugMessage)
+			}
+		})
+
+		t.Run("Cancel", func(t *testing.T) {
+			_, err := client.Checkouts.Cancel(testCheckout.ID)
+			if err != nil {
+				t.Error(err.(*APIError).Details[0].DebugMessage)
+			}
+		})
+	})
+
+	t.Run("Chargebacks", func(t *testing.T) {
+		_, err := client.Chargebacks.List(&ListOptions{Limit: 5})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		card := &Card{
+			Number:   "4242424242424242",
+			Name:     "Rick Sanchez",
+			ExpMonth: "12",
+			ExpYear:  testExpYear,
+		}
+		if _, err := client.Tokens.Create(card, "123"); err == nil {
+			t.Error("failed to detect missing public key")
+		}
+	})
+
 	t.Run("Customers", func(t *testing.T) {
 		testCustomer := &Customer{
 			Name:      "jose",
@@ -77,6 +169,17 @@ func TestConektaClient(t *testing.T) {
 			}
 		})
 
+		t.Run("FindOrCreate", func(t *testing.T) {
+			c := &Customer{Name: "jose", Email: "NUEVO@mail.com"}
+			created, err := client.Customers.FindOrCreate(c, ByEmail)
+			if err != nil {
+				t.Error(err)
+			}
+			if created || c.ID != testCustomer.ID {
+				t.Error("failed to find existing customer")
+			}
+		})
+
 		t.Run("PaymentSource", func(t *testing.T) {
 			t.Run("Create", func(t *testing.T) {
 				err := client.Customers.CreatePaymentSource(testCustomer.ID, "tok_foobar123")
@@ -85,6 +188,29 @@ func TestConektaClient(t *testing.T) {
 				}
 			})
 
+			t.Run("Add", func(t *testing.T) {
+				_, err := client.Customers.AddPaymentSource(testCustomer.ID, &PaymentSourceParams{
+					TokenID: "tok_foobar123",
+				})
+				if err == nil {
+					t.Error(errors.New("failed to detect invalid token"))
+				}
+			})
+
+			t.Run("List", func(t *testing.T) {
+				_, err := client.Customers.ListPaymentSources(testCustomer.ID, nil)
+				if err != nil {
+					t.Error(err.(*APIError).Details[0].DebugMessage)
+				}
+			})
+
+			t.Run("Get", func(t *testing.T) {
+				_, err := client.Customers.GetPaymentSource(testCustomer.ID, "pay_invalid_id")
+				if err == nil {
+					t.Error(errors.New("failed to detect invalid payment source id"))
+				}
+			})
+
 			t.Run("Update", func(t *testing.T) {
 				up := &PaymentSourceUpdate{
 					ID:       "pay_invalid_id",
@@ -137,6 +263,36 @@ func TestConektaClient(t *testing.T) {
 				}
 			})
 
+			t.Run("Get", func(t *testing.T) {
+				c, err := client.Customers.GetShippingContact(testCustomer.ID, contact.ID)
+				if err != nil {
+					t.Error(err.(*APIError).Details[0].DebugMessage)
+				}
+				if c != nil && c.Receiver != contact.Receiver {
+					t.Error("failed to retrieve updated shipping contact")
+				}
+			})
+
+			t.Run("List", func(t *testing.T) {
+				list, err := client.Customers.ListShippingContacts(testCustomer.ID, nil)
+				if err != nil {
+					t.Error(err.(*APIError).Details[0].DebugMessage)
+				}
+				if len(list) == 0 {
+					t.Error("failed to list shipping contacts")
+				}
+			})
+
+			t.Run("Default", func(t *testing.T) {
+				c, err := client.Customers.SetDefaultShippingContact(testCustomer.ID, contact.ID)
+				if err != nil {
+					t.Error(err.(*APIError).Details[0].DebugMessage)
+				}
+				if c != nil && c.DefaultShippingContactID != contact.ID {
+					t.Error("failed to set default shipping contact")
+				}
+			})
+
 			t.Run("Delete", func(t *testing.T) {
 				if err := client.Customers.DeleteShippingContact(testCustomer.ID, contact.ID); err != nil {
 					t.Error(err.(*APIError).Details[0].DebugMessage)
@@ -186,7 +342,7 @@ func TestConektaClient(t *testing.T) {
 						Object:   "payment_source",
 						Type:     "card",
 						ExpMonth: "09",
-						ExpYear:  "19",
+						ExpYear:  testExpYear,
 						Number:   "4242424242424242",
 						Name:     "Rick Sanchez",
 					},
@@ -230,6 +386,13 @@ func TestConektaClient(t *testing.T) {
 			}
 		})
 
+		t.Run("Void", func(t *testing.T) {
+			_, err := client.Orders.Void(testOrder.ID)
+			if err == nil {
+				t.Error("order should not be able to be voided")
+			}
+		})
+
 		t.Run("Refund", func(t *testing.T) {
 			err := client.Orders.Refund(testOrder.ID, &Refund{Reason: "other"})
 			if err != nil {
@@ -237,6 +400,23 @@ func TestConektaClient(t *testing.T) {
 			}
 		})
 
+		t.Run("Charges", func(t *testing.T) {
+			charges, err := client.Orders.ListCharges(testOrder.ID, nil)
+			if err != nil {
+				t.Error(err.(*APIError).Details[0].DebugMessage)
+			}
+			if len(charges) == 0 {
+				t.Fatal("failed to list order charges")
+			}
+			charge, err := client.Orders.GetCharge(testOrder.ID, charges[0].ID)
+			if err != nil {
+				t.Error(err.(*APIError).Details[0].DebugMessage)
+			}
+			if charge != nil && charge.OrderID != testOrder.ID {
+				t.Error("failed to retrieve order charge")
+			}
+		})
+
 		t.Run("LineItem", func(t *testing.T) {
 			itemID := ""
 			t.Run("Create", func(t *testing.T) {
@@ -2,6 +2,7 @@ package conekta
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"path"
 )
@@ -20,10 +21,53 @@ type CustomersAPI interface {
 	// https://developers.conekta.com/api?language=bash#capture-order
 	Delete(customerID string) error
 
-	// Creates new payment source
+	// Retrieves an existing customer
+	// https://developers.conekta.com/api?language=bash#customer
+	Get(customerID string) (*Customer, error)
+
+	// Lists existing customers. If no options are provided all customers are returned
+	// https://developers.conekta.com/api?language=bash#customer
+	List(opts *ListOptions) ([]Customer, error)
+
+	// Returns all customers matching the search term, for example an email
+	// address or phone number
+	// https://developers.conekta.com/api?language=bash#customer
+	Search(term string) ([]Customer, error)
+
+	// Looks for an existing customer with the same identity as the one provided,
+	// creating it if none is found. The customer is updated with the data
+	// registered on the service, and 'true' is returned if it was created.
+	// Concurrent calls on the same client are serialized per identity; if a
+	// concurrent creation from another process is detected the oldest customer
+	// is kept and the duplicate removed. Identities not covered by the service
+	// search, like metadata, require listing all registered customers. If the
+	// duplicates verification fails after creating the customer, 'true' is
+	// returned along with the error
+	FindOrCreate(customer *Customer, identity CustomerIdentity) (bool, error)
+
+	// Creates new card payment source using a token
 	// https://developers.conekta.com/api?language=bash#payment-source
+	//
+	// Deprecated: use 'AddPaymentSource' instead
 	CreatePaymentSource(customerID, tokenID string) error
 
+	// Creates new payment source, of any supported type, and returns it
+	// https://developers.conekta.com/api?language=bash#create-payment-source
+	AddPaymentSource(customerID string, params *PaymentSourceParams) (*PaymentSource, error)
+
+	// Retrieves an existing payment source
+	// https://developers.conekta.com/api?language=bash#payment-source
+	GetPaymentSource(customerID, sourceID string) (*PaymentSource, error)
+
+	// Lists the customer's payment sources. If no options are provided all
+	// payment sources are returned
+	// https://developers.conekta.com/api?language=bash#payment-source
+	ListPaymentSources(customerID string, opts *ListOptions) ([]PaymentSource, error)
+
+	// Sets the payment source used by default to charge the customer
+	// https://developers.conekta.com/api?language=bash#update-customer
+	SetDefaultPaymentSource(customerID, sourceID string) (*Customer, error)
+
 	// Updates existing payment source
 	// https://developers.conekta.com/api?language=bash#update-payment-source
 	UpdatePaymentSource(customerID string, update *PaymentSourceUpdate) error
@@ -44,24 +88,65 @@ type CustomersAPI interface {
 	// https://developers.conekta.com/api?language=bash#update-shipping-contact
 	DeleteShippingContact(customerID, contactID string) error
 
+	// Retrieves an existing Shipping Contact
+	// https://developers.conekta.com/api?language=bash#shipping-contact
+	GetShippingContact(customerID, contactID string) (*ShippingContact, error)
+
+	// Lists the customer's Shipping Contacts. If no options are provided all
+	// shipping contacts are returned
+	// https://developers.conekta.com/api?language=bash#shipping-contact
+	ListShippingContacts(customerID string, opts *ListOptions) ([]ShippingContact, error)
+
+	// Sets the Shipping Contact used by default for the customer's orders
+	// https://developers.conekta.com/api?language=bash#update-customer
+	SetDefaultShippingContact(customerID, contactID string) (*Customer, error)
+
+	// Creates a new Fiscal Entity for an existing customer. Mexican entities
+	// are required to provide a valid RFC as tax ID
+	// https://developers.conekta.com/api?language=bash#create-fiscal-entity
+	CreateFiscalEntity(customerID string, entity *FiscalEntity) error
+
+	// Updates an existing Fiscal Entity
+	// https://developers.conekta.com/api?language=bash#update-fiscal-entity
+	UpdateFiscalEntity(customerID string, entity *FiscalEntity) error
+
+	// Deletes an existing Fiscal Entity
+	// https://developers.conekta.com/api?language=bash#delete-fiscal-entity
+	DeleteFiscalEntity(customerID, entityID string) error
+
+	// Lists the customer's Fiscal Entities. If no options are provided all
+	// fiscal entities are returned
+	// https://developers.conekta.com/api?language=bash#fiscal-entity
+	ListFiscalEntities(customerID string, opts *ListOptions) ([]FiscalEntity, error)
+
 	// Creates a new subscription using tokenized data
 	// https://developers.conekta.com/api?language=bash#create-subscription
+	//
+	// Deprecated: use 'Client.Subscriptions.Create' instead
 	CreateSubscription(customer *Customer, planID, cardID string) error
 
 	// Updates a subscription with a different card or plan
 	// https://developers.conekta.com/api?language=bash#update-subscription
+	//
+	// Deprecated: use 'Client.Subscriptions.Update' instead
 	UpdateSubscription(customer *Customer, planID, cardID string) error
 
 	// Pauses a subscription
 	// https://developers.conekta.com/api?language=bash#pause-subscription
+	//
+	// Deprecated: use 'Client.Subscriptions.Pause' instead
 	PauseSubscription(customerID, subscriptionID string) error
 
 	// Resume a subscription
 	// https://developers.conekta.com/api?language=bash#resume-subscription
+	//
+	// Deprecated: use 'Client.Subscriptions.Resume' instead
 	ResumeSubscription(customerID, subscriptionID string) error
 
 	// Cancel a subscription
-	// https://developers.conekta.com/api?language=bash#resume-subscription
+	// https://developers.conekta.com/api?language=bash#cancel-subscription
+	//
+	// Deprecated: use 'Client.Subscriptions.Cancel' instead
 	CancelSubscription(customerID, subscriptionID string) error
 }
 
@@ -71,6 +156,7 @@ type customersClient struct {
 
 func (cc *customersClient) Create(customer *Customer) error {
 	b, err := cc.c.request(&requestOptions{
+		op:       "customers.create",
 		endpoint: baseUrl + "customers",
 		method:   http.MethodPost,
 		data:     customer,
@@ -84,6 +170,7 @@ func (cc *customersClient) Create(customer *Customer) error {
 
 func (cc *customersClient) Update(customer *Customer) error {
 	b, err := cc.c.request(&requestOptions{
+		op:       "customers.update",
 		endpoint: baseUrl + path.Join("customers", customer.ID),
 		method:   http.MethodPut,
 		data:     customer,
@@ -97,6 +184,7 @@ func (cc *customersClient) Update(customer *Customer) error {
 
 func (cc *customersClient) Delete(customerID string) error {
 	_, err := cc.c.request(&requestOptions{
+		op:       "customers.delete",
 		endpoint: baseUrl + path.Join("customers", customerID),
 		method:   http.MethodDelete,
 		data:     customerID,
@@ -104,24 +192,152 @@ func (cc *customersClient) Delete(customerID string) error {
 	return err
 }
 
+func (cc *customersClient) Get(customerID string) (*Customer, error) {
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.get",
+		endpoint: baseUrl + path.Join("customers", customerID),
+		method:   http.MethodGet,
+	})
+	if err != nil {
+		return nil, err
+	}
+	customer := &Customer{}
+	json.Unmarshal(b, customer)
+	return customer, nil
+}
+
+func (cc *customersClient) List(opts *ListOptions) ([]Customer, error) {
+	return listAll[Customer](cc.c, "customers.list", baseUrl+"customers", opts)
+}
+
+func (cc *customersClient) Search(term string) ([]Customer, error) {
+	var list []Customer
+	opts := &ListOptions{Limit: maxPageSize, Filters: map[string]string{"search": term}}
+	for {
+		page, err := cc.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, page...)
+		if len(page) < int(opts.Limit) {
+			return list, nil
+		}
+		opts.Next = page[len(page)-1].ID
+	}
+}
+
+func (cc *customersClient) FindOrCreate(customer *Customer, identity CustomerIdentity) (bool, error) {
+	value := identity.value(customer)
+	if value == "" {
+		return false, errors.New("customer identity value is required")
+	}
+	defer cc.c.locks.lock(identity.Field + ":" + identity.MetadataKey + ":" + value)()
+
+	// Look for registered customers
+	find := func() ([]Customer, error) {
+		var list []Customer
+		var err error
+		if identity.searchable() {
+			list, err = cc.Search(value)
+		} else {
+			list, err = cc.List(nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return matchCustomers(list, identity, value), nil
+	}
+	found, err := find()
+	if err != nil {
+		return false, err
+	}
+	if len(found) > 0 {
+		*customer = found[0]
+		return false, nil
+	}
+
+	// Create customer and verify no duplicates were created concurrently
+	if err := cc.Create(customer); err != nil {
+		return false, err
+	}
+	found, err = find()
+	if err != nil {
+		return true, err
+	}
+	if len(found) == 0 || found[0].ID == customer.ID {
+		return true, nil
+	}
+	if err := cc.Delete(customer.ID); err != nil {
+		return true, err
+	}
+	*customer = found[0]
+	return false, nil
+}
+
 func (cc *customersClient) CreatePaymentSource(customerID, tokenID string) error {
-	data := map[string]string{
-		"type":     "card",
-		"token_id": tokenID,
+	_, err := cc.AddPaymentSource(customerID, &PaymentSourceParams{Type: PaymentSourceCard, TokenID: tokenID})
+	return err
+}
+
+func (cc *customersClient) AddPaymentSource(customerID string, params *PaymentSourceParams) (*PaymentSource, error) {
+	if params == nil {
+		return nil, errors.New("payment source parameters are required")
 	}
-	_, err := cc.c.request(&requestOptions{
+	data := *params
+	if data.Type == "" {
+		data.Type = PaymentSourceCard
+	}
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.create_payment_source",
 		endpoint: baseUrl + path.Join("customers", customerID, "payment_sources"),
 		method:   http.MethodPost,
 		data:     data,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	source := &PaymentSource{}
+	json.Unmarshal(b, source)
+	return source, nil
+}
+
+func (cc *customersClient) GetPaymentSource(customerID, sourceID string) (*PaymentSource, error) {
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.get_payment_source",
+		endpoint: baseUrl + path.Join("customers", customerID, "payment_sources", sourceID),
+		method:   http.MethodGet,
+	})
+	if err != nil {
+		return nil, err
+	}
+	source := &PaymentSource{}
+	json.Unmarshal(b, source)
+	return source, nil
+}
+
+func (cc *customersClient) ListPaymentSources(customerID string, opts *ListOptions) ([]PaymentSource, error) {
+	endpoint := baseUrl + path.Join("customers", customerID, "payment_sources")
+	return listAll[PaymentSource](cc.c, "customers.list_payment_sources", endpoint, opts)
+}
+
+func (cc *customersClient) SetDefaultPaymentSource(customerID, sourceID string) (*Customer, error) {
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.set_default_payment_source",
+		endpoint: baseUrl + path.Join("customers", customerID),
+		method:   http.MethodPut,
+		data:     map[string]string{"default_payment_source_id": sourceID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	customer := &Customer{}
+	json.Unmarshal(b, customer)
+	return customer, nil
 }
 
 func (cc *customersClient) UpdatePaymentSource(customerID string, update *PaymentSourceUpdate) error {
 	_, err := cc.c.request(&requestOptions{
+		op:       "customers.update_payment_source",
 		endpoint: baseUrl + path.Join("customers", customerID, "payment_sources", update.ID),
 		method:   http.MethodPut,
 		data:     update,
@@ -131,6 +347,7 @@ func (cc *customersClient) UpdatePaymentSource(customerID string, update *Paymen
 
 func (cc *customersClient) DeletePaymentSource(customerID, sourceID string) error {
 	_, err := cc.c.request(&requestOptions{
+		op:       "customers.delete_payment_source",
 		endpoint: baseUrl + path.Join("customers", customerID, "payment_sources", sourceID),
 		method:   http.MethodDelete,
 		data:     customerID,
@@ -140,6 +357,7 @@ func (cc *customersClient) DeletePaymentSource(customerID, sourceID string) erro
 
 func (cc *customersClient) CreateShippingContact(customerID string, contact *ShippingContact) error {
 	b, err := cc.c.request(&requestOptions{
+		op:       "customers.create_shipping_contact",
 		endpoint: baseUrl + path.Join("customers", customerID, "shipping_contacts"),
 		method:   http.MethodPost,
 		data:     contact,
@@ -152,7 +370,8 @@ func (cc *customersClient) CreateShippingContact(customerID string, contact *Shi
 }
 
 func (cc *customersClient) UpdateShippingContact(customerID string, contact *ShippingContact) error {
-	_, err := cc.c.request(&requestOptions{
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.update_shipping_contact",
 		endpoint: baseUrl + path.Join("customers", customerID, "shipping_contacts", contact.ID),
 		method:   http.MethodPut,
 		data:     contact,
@@ -160,11 +379,13 @@ func (cc *customersClient) UpdateShippingContact(customerID string, contact *Shi
 	if err != nil {
 		return err
 	}
+	json.Unmarshal(b, contact)
 	return nil
 }
 
 func (cc *customersClient) DeleteShippingContact(customerID, contactID string) error {
 	_, err := cc.c.request(&requestOptions{
+		op:       "customers.delete_shipping_contact",
 		endpoint: baseUrl + path.Join("customers", customerID, "shipping_contacts", contactID),
 		method:   http.MethodDelete,
 		data:     customerID,
@@ -172,55 +393,112 @@ func (cc *customersClient) DeleteShippingContact(customerID, contactID string) e
 	return err
 }
 
-func (cc *customersClient) CreateSubscription(customer *Customer, planID, cardID string) error {
-	data := map[string]string{"plan": planID}
-	if cardID != "" {
-		data["card"] = cardID
+func (cc *customersClient) GetShippingContact(customerID, contactID string) (*ShippingContact, error) {
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.get_shipping_contact",
+		endpoint: baseUrl + path.Join("customers", customerID, "shipping_contacts", contactID),
+		method:   http.MethodGet,
+	})
+	if err != nil {
+		return nil, err
 	}
-	_, err := cc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("customers", customer.ID, "subscription"),
+	contact := &ShippingContact{}
+	json.Unmarshal(b, contact)
+	return contact, nil
+}
+
+func (cc *customersClient) ListShippingContacts(customerID string, opts *ListOptions) ([]ShippingContact, error) {
+	endpoint := baseUrl + path.Join("customers", customerID, "shipping_contacts")
+	return listAll[ShippingContact](cc.c, "customers.list_shipping_contacts", endpoint, opts)
+}
+
+func (cc *customersClient) SetDefaultShippingContact(customerID, contactID string) (*Customer, error) {
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.set_default_shipping_contact",
+		endpoint: baseUrl + path.Join("customers", customerID),
+		method:   http.MethodPut,
+		data:     map[string]string{"default_shipping_contact_id": contactID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	customer := &Customer{}
+	json.Unmarshal(b, customer)
+	return customer, nil
+}
+
+func (cc *customersClient) CreateFiscalEntity(customerID string, entity *FiscalEntity) error {
+	if err := validateFiscalEntity(entity); err != nil {
+		return err
+	}
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.create_fiscal_entity",
+		endpoint: baseUrl + path.Join("customers", customerID, "fiscal_entities"),
 		method:   http.MethodPost,
-		data:     data,
+		data:     entity,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	json.Unmarshal(b, entity)
+	return nil
 }
 
-func (cc *customersClient) UpdateSubscription(customer *Customer, planID, cardID string) error {
-	data := map[string]string{"plan": planID}
-	if cardID != "" {
-		data["card"] = cardID
+func (cc *customersClient) UpdateFiscalEntity(customerID string, entity *FiscalEntity) error {
+	if entity.TaxID != "" {
+		if err := validateFiscalEntity(entity); err != nil {
+			return err
+		}
 	}
-	_, err := cc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("customers", customer.ID, "subscription"),
+	b, err := cc.c.request(&requestOptions{
+		op:       "customers.update_fiscal_entity",
+		endpoint: baseUrl + path.Join("customers", customerID, "fiscal_entities", entity.ID),
 		method:   http.MethodPut,
-		data:     data,
+		data:     entity,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	json.Unmarshal(b, entity)
+	return nil
 }
 
-func (cc *customersClient) PauseSubscription(customerID, subscriptionID string) error {
+func (cc *customersClient) DeleteFiscalEntity(customerID, entityID string) error {
 	_, err := cc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("customers", customerID, "subscription", "pause"),
-		method:   http.MethodPost,
-		data:     map[string]string{"id": subscriptionID},
+		op:       "customers.delete_fiscal_entity",
+		endpoint: baseUrl + path.Join("customers", customerID, "fiscal_entities", entityID),
+		method:   http.MethodDelete,
+		data:     entityID,
 	})
 	return err
 }
 
+func (cc *customersClient) ListFiscalEntities(customerID string, opts *ListOptions) ([]FiscalEntity, error) {
+	endpoint := baseUrl + path.Join("customers", customerID, "fiscal_entities")
+	return listAll[FiscalEntity](cc.c, "customers.list_fiscal_entities", endpoint, opts)
+}
+
+func (cc *customersClient) CreateSubscription(customer *Customer, planID, cardID string) error {
+	_, err := cc.c.Subscriptions.Create(customer.ID, &SubscriptionParams{PlanID: planID, CardID: cardID})
+	return err
+}
+
+func (cc *customersClient) UpdateSubscription(customer *Customer, planID, cardID string) error {
+	_, err := cc.c.Subscriptions.Update(customer.ID, &SubscriptionParams{PlanID: planID, CardID: cardID})
+	return err
+}
+
+func (cc *customersClient) PauseSubscription(customerID, subscriptionID string) error {
+	_, err := legacySubscriptionAction(cc.c, "subscriptions.pause", customerID, subscriptionID, "pause")
+	return err
+}
+
 func (cc *customersClient) ResumeSubscription(customerID, subscriptionID string) error {
-	_, err := cc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("customers", customerID, "subscription", "resume"),
-		method:   http.MethodPost,
-		data:     map[string]string{"id": subscriptionID},
-	})
+	_, err := legacySubscriptionAction(cc.c, "subscriptions.resume", customerID, subscriptionID, "resume")
 	return err
 }
 
 func (cc *customersClient) CancelSubscription(customerID, subscriptionID string) error {
-	_, err := cc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("customers", customerID, "subscription", "cancel"),
-		method:   http.MethodPost,
-		data:     map[string]string{"id": subscriptionID},
-	})
+	_, err := legacySubscriptionAction(cc.c, "subscriptions.cancel", customerID, subscriptionID, "cancel")
 	return err
 }
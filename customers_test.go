@@ -0,0 +1,157 @@
+package conekta
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPaymentSources(t *testing.T) {
+	rt := &recordingTransport{reply: `{"id": "src_1", "type": "card", "last4": "4242", "brand": "visa"}`}
+	client := recordingClient(t, rt)
+
+	t.Run("Add", func(t *testing.T) {
+		src, err := client.Customers.AddPaymentSource("cus_1", &PaymentSourceParams{TokenID: "tok_1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if src.ID != "src_1" || src.Last4 != "4242" {
+			t.Errorf("invalid payment source: %+v", src)
+		}
+		if _, err := client.Customers.AddPaymentSource("cus_1", nil); err == nil {
+			t.Error("failed to detect missing parameters")
+		}
+	})
+
+	t.Run("Deprecated", func(t *testing.T) {
+		rt.requests, rt.bodies = nil, nil
+		if err := client.Customers.CreatePaymentSource("cus_1", "tok_1"); err != nil {
+			t.Fatal(err)
+		}
+		req := rt.requests[0]
+		if req.Method != http.MethodPost || req.URL.Path != "/customers/cus_1/payment_sources" {
+			t.Errorf("invalid request: %s %s", req.Method, req.URL.Path)
+		}
+		if rt.bodies[0] != `{"type":"card","token_id":"tok_1"}` {
+			t.Errorf("invalid payload: %s", rt.bodies[0])
+		}
+	})
+}
+
+// Return a list response holding the provided customers
+func customerList(customers ...string) string {
+	return `{"object": "list", "data": [` + strings.Join(customers, ",") + `]}`
+}
+
+func TestCustomerSearch(t *testing.T) {
+	page := make([]string, maxPageSize)
+	for i := range page {
+		page[i] = fmt.Sprintf(`{"id": "cus_%d"}`, i)
+	}
+	rt := &recordingTransport{replies: []string{customerList(page...), customerList(`{"id": "cus_last"}`)}}
+	client := recordingClient(t, rt)
+
+	list, err := client.Customers.Search("rick@citadel.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != maxPageSize+1 || list[maxPageSize].ID != "cus_last" {
+		t.Errorf("failed to retrieve all pages: %d", len(list))
+	}
+	if len(rt.requests) != 2 {
+		t.Fatalf("unexpected requests: %d", len(rt.requests))
+	}
+	q := rt.requests[1].URL.Query()
+	if q.Get("search") != "rick@citadel.com" || q.Get("next") != fmt.Sprintf("cus_%d", maxPageSize-1) {
+		t.Errorf("invalid pagination query: %s", rt.requests[1].URL.RawQuery)
+	}
+}
+
+func TestFindOrCreate(t *testing.T) {
+	t.Run("Email", func(t *testing.T) {
+		rt := &recordingTransport{reply: customerList(
+			`{"id": "cus_2", "email": "RICK@citadel.com", "created_at": 20}`,
+			`{"id": "cus_3", "email": "morty@citadel.com", "created_at": 5}`,
+			`{"id": "cus_1", "email": "rick@citadel.com", "created_at": 10}`,
+		)}
+		client := recordingClient(t, rt)
+		customer := &Customer{Email: " Rick@Citadel.com "}
+		created, err := client.Customers.FindOrCreate(customer, ByEmail)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created || customer.ID != "cus_1" {
+			t.Errorf("oldest matching customer should be used: %+v", customer)
+		}
+		if len(rt.requests) != 1 || rt.requests[0].URL.Query().Get("search") != "rick@citadel.com" {
+			t.Error("search should use the normalized email")
+		}
+	})
+
+	t.Run("Phone", func(t *testing.T) {
+		rt := &recordingTransport{reply: customerList(`{"id": "cus_1", "phone": "+525511223344"}`)}
+		client := recordingClient(t, rt)
+		customer := &Customer{Phone: "+52 (55) 1122-3344"}
+		created, err := client.Customers.FindOrCreate(customer, ByPhone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created || customer.ID != "cus_1" {
+			t.Errorf("failed to match formatted phone: %+v", customer)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		rt := &recordingTransport{replies: []string{
+			customerList(),
+			`{"id": "cus_new", "email": "rick@citadel.com"}`,
+			customerList(`{"id": "cus_new", "email": "rick@citadel.com"}`),
+		}}
+		client := recordingClient(t, rt)
+		customer := &Customer{Email: "rick@citadel.com"}
+		created, err := client.Customers.FindOrCreate(customer, ByEmail)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !created || customer.ID != "cus_new" || len(rt.requests) != 3 {
+			t.Errorf("failed to create customer: %+v", customer)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		rt := &recordingTransport{replies: []string{
+			customerList(),
+			`{"id": "cus_new", "email": "rick@citadel.com", "created_at": 20}`,
+			customerList(
+				`{"id": "cus_new", "email": "rick@citadel.com", "created_at": 20}`,
+				`{"id": "cus_old", "email": "rick@citadel.com", "created_at": 10}`,
+			),
+			`{"id": "cus_new", "deleted": true}`,
+		}}
+		client := recordingClient(t, rt)
+		customer := &Customer{Email: "rick@citadel.com"}
+		created, err := client.Customers.FindOrCreate(customer, ByEmail)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created || customer.ID != "cus_old" {
+			t.Errorf("concurrent duplicate should be discarded: %+v", customer)
+		}
+		if len(rt.requests) != 4 {
+			t.Fatalf("unexpected requests: %d", len(rt.requests))
+		}
+		req := rt.requests[3]
+		if req.Method != http.MethodDelete || req.URL.Path != "/customers/cus_new" {
+			t.Errorf("invalid request: %s %s", req.Method, req.URL.Path)
+		}
+	})
+
+	t.Run("Identity", func(t *testing.T) {
+		rt := &recordingTransport{}
+		client := recordingClient(t, rt)
+		if _, err := client.Customers.FindOrCreate(&Customer{}, ByEmail); err == nil || len(rt.requests) != 0 {
+			t.Error("failed to detect missing identity value")
+		}
+	})
+}
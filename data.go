@@ -1,5 +1,11 @@
 package conekta
 
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
 // https://developers.conekta.com/api?language=bash#shipping-contact
 type Address struct {
 	// The first line for the shipping address. Usually used for the street and the number
@@ -43,6 +49,9 @@ type ShippingContact struct {
 
 	// Shipping address
 	Address Address `json:"address,omitempty"`
+
+	// Whether this is the customer's default shipping contact
+	Default bool `json:"default,omitempty"`
 }
 
 // Represents the line items in the order.
@@ -73,7 +82,7 @@ type LineItem struct {
 	Brand string `json:"brand,omitempty"`
 
 	// Map containing additional information related to the line item (optional)
-	Metadata map[string]string `json:"metadata,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // A Shipping Line describes the shipment details for an order such as the method,
@@ -96,7 +105,7 @@ type ShippingLine struct {
 	Method string `json:"method,omitempty"`
 
 	// Map containing additional information related to the shipping line (optional)
-	Metadata map[string]string `json:"metadata,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // Describes the taxes of the order.
@@ -112,7 +121,7 @@ type TaxLine struct {
 	Amount uint32 `json:"amount,omitempty"`
 
 	// Map containing additional information related to the tax line (optional)
-	Metadata map[string]string `json:"metadata,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // Describes the discounts to be applied to the order
@@ -186,6 +195,56 @@ type Charge struct {
 
 	// false: Sandbox Mode. true: Production Mode
 	Livemode bool `json:"livemode"`
+
+	// Refunds applied to the charge
+	Refunds RefundList `json:"refunds,omitempty"`
+
+	// Chargeback raised by the card holder against the charge, if any
+	Chargeback *Chargeback `json:"chargeback,omitempty"`
+
+	// Action the buyer must complete for the charge to proceed, set by the system
+	NextAction *NextAction `json:"next_action,omitempty"`
+
+	// Code of the reason the charge was declined, if any
+	FailureCode string `json:"failure_code,omitempty"`
+
+	// Human-readable reason the charge was declined, if any
+	FailureMessage string `json:"failure_message,omitempty"`
+
+	// Map containing additional information related to the charge (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Charges of an order. The service may encode them as a plain array or as a
+// list object, both are supported when decoding
+type ChargeList []Charge
+
+func (cl *ChargeList) UnmarshalJSON(b []byte) error {
+	items, err := decodeList[Charge](b)
+	if err != nil {
+		return err
+	}
+	*cl = items
+	return nil
+}
+
+// Decode a collection encoded either as a plain array or as a list object
+func decodeList[T any](b []byte) ([]T, error) {
+	var items []T
+	if err := json.Unmarshal(b, &items); err == nil {
+		return items, nil
+	}
+	l := &listResponse{}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, err
+	}
+	items = nil
+	if len(l.Data) > 0 {
+		if err := json.Unmarshal(l.Data, &items); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
 }
 
 // A refund details the amount and reason why an order was refunded
@@ -204,8 +263,184 @@ type Refund struct {
 
 	// If you want to partially refund and order
 	Amount uint32 `json:"amount,omitempty"`
+
+	// Charge to refund, for orders paid with multiple charges (optional)
+	ChargeID string `json:"charge_id,omitempty"`
+
+	// Object class. In this case, "refund"
+	Object string `json:"object,omitempty"`
+
+	// Status of the refund, set by the system
+	Status string `json:"status,omitempty"`
+
+	// Date when the refund was created
+	CreatedAt uint32 `json:"created_at,omitempty"`
+
+	// Map containing additional information related to the refund (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Refunds applied to a charge. The service may encode them as a plain array
+// or as a list object, both are supported when decoding
+type RefundList []Refund
+
+func (rl *RefundList) UnmarshalJSON(b []byte) error {
+	items, err := decodeList[Refund](b)
+	if err != nil {
+		return err
+	}
+	*rl = items
+	return nil
+}
+
+// The service reports the refunds of a charge with negative amounts, decode
+// them as the absolute value
+func (r *Refund) UnmarshalJSON(b []byte) error {
+	type refund Refund
+	aux := struct {
+		*refund
+		Amount int64 `json:"amount,omitempty"`
+	}{refund: (*refund)(r)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.Amount < 0 {
+		aux.Amount = -aux.Amount
+	}
+	r.Amount = uint32(aux.Amount)
+	return nil
+}
+
+// Total amount refunded, in cents
+func (rl RefundList) Amount() uint32 {
+	var total uint32
+	for _, r := range rl {
+		total += r.Amount
+	}
+	return total
+}
+
+// Chargeback status values reported by the service
+const (
+	ChargebackPending     = "pending"
+	ChargebackUnderReview = "under_review"
+	ChargebackWon         = "won"
+	ChargebackLost        = "lost"
+	ChargebackAccepted    = "accepted"
+)
+
+// A chargeback (dispute) is raised when a card holder questions a charge with
+// their bank. Evidence can be submitted to contest it before its due date
+// https://developers.conekta.com/api?language=bash#chargeback
+type Chargeback struct {
+	// Unique identifier, assigned at random
+	ID string `json:"id,omitempty"`
+
+	// Object class. In this case, "chargeback"
+	Object string `json:"object,omitempty"`
+
+	// Status of the chargeback: pending, under_review, won, lost or accepted
+	Status string `json:"status,omitempty"`
+
+	// Reason reported by the card holder's bank
+	Reason string `json:"reason,omitempty"`
+
+	// Disputed amount, in cents
+	Amount uint32 `json:"amount,omitempty"`
+
+	// Currency of the disputed amount, ISO 4217
+	Currency string `json:"currency,omitempty"`
+
+	// Id of the disputed charge
+	ChargeID string `json:"charge_id,omitempty"`
+
+	// Id of the order the disputed charge belongs to
+	OrderID string `json:"order_id,omitempty"`
+
+	// Date when the chargeback was created
+	CreatedAt uint32 `json:"created_at,omitempty"`
+
+	// Date until evidence can be submitted
+	EvidenceDueBy uint32 `json:"evidence_due_by,omitempty"`
+
+	// Evidence submitted, if any
+	Evidence *ChargebackEvidence `json:"evidence,omitempty"`
+
+	// false: Sandbox Mode. true: Production Mode
+	Livemode bool `json:"livemode"`
 }
 
+// Evidence submitted to contest a chargeback
+type ChargebackEvidence struct {
+	// Name of the customer
+	CustomerName string `json:"customer_name,omitempty"`
+
+	// Email of the customer
+	CustomerEmail string `json:"customer_email,omitempty"`
+
+	// Description of the product or service sold
+	ProductDescription string `json:"product_description,omitempty"`
+
+	// Shipping carrier used to deliver the product
+	ShippingCarrier string `json:"shipping_carrier,omitempty"`
+
+	// Tracking number of the shipment
+	ShippingTrackingNumber string `json:"shipping_tracking_number,omitempty"`
+
+	// Date when the product was delivered
+	ShippingDate uint32 `json:"shipping_date,omitempty"`
+
+	// URLs of supporting documents, for example receipts or signed delivery notes
+	DocumentURLs []string `json:"document_urls,omitempty"`
+
+	// Any additional information supporting the case
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// 3-D Secure authentication modes
+const (
+	// Authentication is requested only when the service considers it necessary
+	ThreeDSSmart = "smart"
+
+	// Authentication is always requested
+	ThreeDSStrict = "strict"
+)
+
+// Next action types reported by the service
+const NextActionRedirectToURL = "redirect_to_url"
+
+// Describes an action the buyer must complete for a payment to proceed, for
+// example a 3-D Secure challenge
+type NextAction struct {
+	// Action type. Currently only "redirect_to_url"
+	Type string `json:"type,omitempty"`
+
+	// Redirection details
+	RedirectToURL *RedirectToURL `json:"redirect_to_url,omitempty"`
+}
+
+// Redirection required to complete an action
+type RedirectToURL struct {
+	// URL the buyer must be redirected to
+	URL string `json:"url,omitempty"`
+
+	// URL the buyer returns to after completing the action
+	ReturnURL string `json:"return_url,omitempty"`
+}
+
+// Order payment status values reported by the service
+const (
+	OrderPaymentPending    = "payment_pending"
+	OrderDeclined          = "declined"
+	OrderExpired           = "expired"
+	OrderPaid              = "paid"
+	OrderRefunded          = "refunded"
+	OrderPartiallyRefunded = "partially_refunded"
+	OrderChargedBack       = "charged_back"
+	OrderPreAuthorized     = "pre_authorized"
+	OrderVoided            = "voided"
+)
+
 // An Order represents a purchase. It contains all the details related to it, including
 // payment method, shipment, charges, discounts, taxes and the products.
 // https://developers.conekta.com/api?language=bash#order
@@ -241,7 +476,7 @@ type Order struct {
 	Livemode bool `json:"livemode"`
 
 	// Map containing additional information related to the order
-	Metadata map[string]string `json:"metadata,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
 
 	// Mandatory when a shipping_line is included in the order. If the order is sent without a
 	// shipping_contact, the customer's default shipping_contact will be used
@@ -260,11 +495,26 @@ type Order struct {
 	// Information about the order's customer
 	CustomerInfo CustomerInfo `json:"customer_info,omitempty"`
 
+	// Fiscal information used to invoice the order (optional)
+	FiscalEntity *FiscalEntity `json:"fiscal_entity,omitempty"`
+
 	// List of the charges generated to cover the order amount
-	Charges []Charge `json:"charges,omitempty"`
+	Charges ChargeList `json:"charges,omitempty"`
 
 	// States if the charges of the order should be preauthorized
 	PreAuthorize bool `json:"pre_authorize"`
+
+	// Hosted checkout used to pay the order, instead of providing charges (optional)
+	Checkout *Checkout `json:"checkout,omitempty"`
+
+	// 3-D Secure authentication mode for card charges: smart or strict (optional)
+	ThreeDSMode string `json:"three_ds_mode,omitempty"`
+
+	// URL the buyer is redirected to after completing 3-D Secure authentication
+	ReturnURL string `json:"return_url,omitempty"`
+
+	// Action the buyer must complete for the payment to proceed, set by the system
+	NextAction *NextAction `json:"next_action,omitempty"`
 }
 
 // The Payment Source object describes a payment method. This can be online (card payments)
@@ -278,12 +528,15 @@ type PaymentSource struct {
 	// Object's class. In this case "payment_source"
 	Object string `json:"object,omitempty"`
 
-	// Payment source's type. At the time the only type supported is "card"
+	// Payment source's type: "card" or "oxxo_recurrent"
 	Type string `json:"type,omitempty"`
 
 	// Date when the payment source was created
 	CreatedAt uint32 `json:"created_at,omitempty"`
 
+	// Whether this is the customer's default payment source
+	Default bool `json:"default,omitempty"`
+
 	// Last 4 digits of the card
 	Last4 string `json:"last4,omitempty"`
 
@@ -301,6 +554,31 @@ type PaymentSource struct {
 
 	// Id of the customer that owns the payment source
 	ParentID string `json:"parent_id,omitempty"`
+
+	// Payment reference for "oxxo_recurrent" sources
+	Reference string `json:"reference,omitempty"`
+
+	// Barcode of the payment reference for "oxxo_recurrent" sources
+	Barcode string `json:"barcode,omitempty"`
+
+	// URL of the barcode image for "oxxo_recurrent" sources
+	BarcodeURL string `json:"barcode_url,omitempty"`
+}
+
+// Payment source types supported by the service
+const (
+	PaymentSourceCard          = "card"
+	PaymentSourceOXXORecurrent = "oxxo_recurrent"
+)
+
+// Parameters used to create a new payment source
+// https://developers.conekta.com/api?language=bash#create-payment-source
+type PaymentSourceParams struct {
+	// Payment source's type, defaults to "card"
+	Type string `json:"type"`
+
+	// Id of the card token, required for "card" sources
+	TokenID string `json:"token_id,omitempty"`
 }
 
 // Updates an existing payment source
@@ -356,9 +634,70 @@ type Subscription struct {
 	// Id of the plan assigned to the subscription
 	PlanID string `json:"plan_id,omitempty"`
 
+	// Id of the customer that owns the subscription
+	CustomerID string `json:"customer_id,omitempty"`
+
+	// Id of the card used to bill the subscription
+	CardID string `json:"card_id,omitempty"`
+
 	// Status of the subscription. Allowed values are:
 	// in_trial, active, past_due, paused, and canceled
 	Status string `json:"status,omitempty"`
+
+	// Map containing additional information related to the subscription (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Parameters used to create or update a subscription
+// https://developers.conekta.com/api?language=bash#create-subscription
+type SubscriptionParams struct {
+	// Id of the subscription to update, required only for customers with
+	// multiple subscriptions
+	ID string `json:"id,omitempty"`
+
+	// Id of the plan to assign to the subscription
+	PlanID string `json:"plan,omitempty"`
+
+	// Id of the card to bill, if not provided the customer's default
+	// payment source is used (optional)
+	CardID string `json:"card,omitempty"`
+
+	// Date when the trial ends, overrides the plan's trial period (optional)
+	TrialEnd uint32 `json:"trial_end,omitempty"`
+
+	// Map containing additional information related to the subscription (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Fiscal entities hold the tax information required to issue invoices (CFDI)
+// https://developers.conekta.com/api?language=bash#fiscal-entity
+type FiscalEntity struct {
+	// Unique identifier, assigned at random
+	ID string `json:"id,omitempty"`
+
+	// Object class. In this case, "fiscal_entity"
+	Object string `json:"object,omitempty"`
+
+	// Tax identifier. For Mexican entities the RFC
+	TaxID string `json:"tax_id,omitempty"`
+
+	// Registered name of the entity
+	CompanyName string `json:"company_name,omitempty"`
+
+	// Contact email for invoicing purposes (optional)
+	Email string `json:"email,omitempty"`
+
+	// Contact phone for invoicing purposes (optional)
+	Phone string `json:"phone,omitempty"`
+
+	// Fiscal address
+	Address Address `json:"address,omitempty"`
+
+	// Map containing additional information related to the fiscal entity (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+
+	// Id of the customer that owns the fiscal entity
+	ParentID string `json:"parent_id,omitempty"`
 }
 
 // Customers allow you to store payment methods for clients and set up subscriptions
@@ -367,6 +706,9 @@ type Customer struct {
 	// Customer's unique identifier
 	ID string `json:"id,omitempty"`
 
+	// Date when the customer was created
+	CreatedAt uint32 `json:"created_at,omitempty"`
+
 	// Customer's name
 	Name string `json:"name,omitempty"`
 
@@ -379,6 +721,12 @@ type Customer struct {
 	// Plan secondary id
 	PlanID string `json:"plan_id,omitempty"`
 
+	// Id of the payment source used by default
+	DefaultPaymentSourceID string `json:"default_payment_source_id,omitempty"`
+
+	// Id of the shipping contact used by default
+	DefaultShippingContactID string `json:"default_shipping_contact_id,omitempty"`
+
 	// Indicates whether a user is corporate or not
 	Corporate bool `json:"corporate"`
 
@@ -388,9 +736,15 @@ type Customer struct {
 	// Shipping contacts available
 	ShippingContacts []ShippingContact `json:"shipping_contacts,omitempty"`
 
+	// Fiscal entities available for invoicing
+	FiscalEntities []FiscalEntity `json:"fiscal_entities,omitempty"`
+
 	// Subscriptions bill your client a fixed amount on a recurring basis.
 	// You can change the plan of a subscription, pause, cancel and resume a subscription
 	Subscriptions []Subscription `json:"subscriptions,omitempty"`
+
+	// Map containing additional information related to the customer (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // Plans are templates for subscriptions. They allow you to define the amount and
@@ -431,9 +785,12 @@ type Plan struct {
 
 	// Number of charges that will be made before the subscription expires
 	ExpiryCount uint32 `json:"expiry_count,omitempty"`
+
+	// Map containing additional information related to the plan (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
-// Updates plan data
+// Updates plan data. Fields with zero values are not modified
 // https://developers.conekta.com/api?language=bash#update-plan
 type PlanUpdate struct {
 	// Unique plan identifier. Remember you can't change it
@@ -444,6 +801,24 @@ type PlanUpdate struct {
 
 	// Charge's amount in cents
 	Amount uint32 `json:"amount,omitempty"`
+
+	// Currency of the charge. A 3-letter code of the International Standard ISO 4217
+	Currency string `json:"currency,omitempty"`
+
+	// The interval for the charge: week, half_month, month or year
+	Interval string `json:"interval,omitempty"`
+
+	// The frequency for the charge
+	Frequency uint32 `json:"frequency,omitempty"`
+
+	// Days of the trial's duration
+	TrialPeriodDays uint32 `json:"trial_period_days,omitempty"`
+
+	// Number of charges that will be made before the subscription expires
+	ExpiryCount uint32 `json:"expiry_count,omitempty"`
+
+	// Map containing additional information related to the plan (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // Card enable to charge orders directly to a user plastic card
@@ -470,4 +845,200 @@ type Card struct {
 
 	// Card's holder name
 	Name string `json:"name,omitempty"`
+
+	// Id of a card token to charge, used instead of the card details
+	TokenID string `json:"token_id,omitempty"`
+
+	// Id of a customer's payment source to charge, used instead of the card details
+	PaymentSourceID string `json:"payment_source_id,omitempty"`
+
+	// Last 4 digits of the card, set by the system
+	Last4 string `json:"last4,omitempty"`
+}
+
+// Tokens represent card details collected and stored by the service, they
+// can be used once to charge the card or to create a payment source
+// https://developers.conekta.com/api?language=bash#token
+type Token struct {
+	// Unique identifier, assigned at random
+	ID string `json:"id,omitempty"`
+
+	// Object class. In this case, "token"
+	Object string `json:"object,omitempty"`
+
+	// Whether the token was already used
+	Used bool `json:"used"`
+
+	// false: Sandbox Mode. true: Production Mode
+	Livemode bool `json:"livemode"`
+}
+
+// Checkout types supported by the service
+const (
+	CheckoutPaymentLink   = "PaymentLink"
+	CheckoutHostedPayment = "HostedPayment"
+	CheckoutIntegration   = "Integration"
+)
+
+// Payment methods available on checkouts
+const (
+	PaymentMethodCash         = "cash"
+	PaymentMethodCard         = "card"
+	PaymentMethodBankTransfer = "bank_transfer"
+)
+
+// Checkouts allow to collect payments through a page hosted by the service, for
+// example sending the buyer a payment link instead of collecting card data
+// https://developers.conekta.com/api?language=bash#checkout
+type Checkout struct {
+	// Unique identifier assigned at random
+	ID string `json:"id,omitempty"`
+
+	// Object class. In this case, "checkout"
+	Object string `json:"object,omitempty"`
+
+	// Checkout's type: PaymentLink, HostedPayment or Integration
+	Type string `json:"type,omitempty"`
+
+	// Checkout's name, shown to the buyer
+	Name string `json:"name,omitempty"`
+
+	// Status of the checkout, set by the system
+	Status string `json:"status,omitempty"`
+
+	// URL of the checkout page to share with the buyer, set by the system
+	URL string `json:"url,omitempty"`
+
+	// Unique slug of the checkout page, set by the system
+	Slug string `json:"slug,omitempty"`
+
+	// Date when the checkout was created
+	CreatedAt uint32 `json:"created_at,omitempty"`
+
+	// Date when the checkout expires
+	ExpiresAt uint32 `json:"expires_at,omitempty"`
+
+	// false: Sandbox Mode. true: Production Mode
+	Livemode bool `json:"livemode,omitempty"`
+
+	// Whether the payment link can be paid several times
+	Recurrent bool `json:"recurrent,omitempty"`
+
+	// Payment methods offered to the buyer: cash, card and bank_transfer
+	AllowedPaymentMethods []string `json:"allowed_payment_methods,omitempty"`
+
+	// Whether the buyer is required to provide a shipping contact
+	NeedsShippingContact bool `json:"needs_shipping_contact,omitempty"`
+
+	// Whether monthly installments are offered for card payments
+	MonthlyInstallmentsEnabled bool `json:"monthly_installments_enabled,omitempty"`
+
+	// Monthly installments offered to the buyer: 3, 6, 9 and 12
+	MonthlyInstallmentsOptions []uint32 `json:"monthly_installments_options,omitempty"`
+
+	// URL the buyer is redirected to after a successful payment
+	SuccessURL string `json:"success_url,omitempty"`
+
+	// URL the buyer is redirected to after a failed payment
+	FailureURL string `json:"failure_url,omitempty"`
+
+	// Order to create when the checkout is paid, required for payment links
+	OrderTemplate *CheckoutOrderTemplate `json:"order_template,omitempty"`
+
+	// Id of the order paid through the checkout, for order level checkouts
+	OrderID string `json:"order_id,omitempty"`
+
+	// Map containing additional information related to the checkout (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Details of the order created when a payment link is paid
+type CheckoutOrderTemplate struct {
+	// Currency of the order, ISO 4217
+	Currency string `json:"currency,omitempty"`
+
+	// List of the products being sold
+	LineItems []LineItem `json:"line_items,omitempty"`
+
+	// Information about the order's customer (optional)
+	CustomerInfo *CustomerInfo `json:"customer_info,omitempty"`
+
+	// Map containing additional information related to the order (optional)
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Events notify about changes on resources, they are usually delivered to a
+// webhook listener
+// https://developers.conekta.com/api?language=bash#event
+type Event struct {
+	// Unique identifier
+	ID string `json:"id,omitempty"`
+
+	// Object class. In this case, "event"
+	Object string `json:"object,omitempty"`
+
+	// Event type, for example 'subscription.payment_failed'
+	Type string `json:"type,omitempty"`
+
+	// Date when the event was created
+	CreatedAt uint32 `json:"created_at,omitempty"`
+
+	// false: Sandbox Mode. true: Production Mode
+	Livemode bool `json:"livemode"`
+
+	// Resource affected by the event
+	Data EventData `json:"data"`
+}
+
+// Resource information included in an event
+type EventData struct {
+	// Encoded resource, its type depends on the event type
+	Object json.RawMessage `json:"object,omitempty"`
+
+	// Values of the attributes changed by the event, before the change
+	PreviousAttributes map[string]interface{} `json:"previous_attributes,omitempty"`
+}
+
+// Chargeback decodes the chargeback included in a 'charge.chargeback.*' event.
+// Both chargeback objects and charges including a chargeback are supported
+func (e *Event) Chargeback() (*Chargeback, error) {
+	if !strings.HasPrefix(e.Type, "charge.chargeback.") {
+		return nil, errors.New("not a chargeback event: " + e.Type)
+	}
+	obj := struct {
+		Object string `json:"object"`
+	}{}
+	if err := json.Unmarshal(e.Data.Object, &obj); err != nil {
+		return nil, err
+	}
+	if obj.Object == "charge" {
+		charge := &Charge{}
+		if err := json.Unmarshal(e.Data.Object, charge); err != nil {
+			return nil, err
+		}
+		if charge.Chargeback == nil {
+			return nil, errors.New("charge without chargeback information")
+		}
+		if charge.Chargeback.ChargeID == "" {
+			charge.Chargeback.ChargeID = charge.ID
+		}
+		return charge.Chargeback, nil
+	}
+	cb := &Chargeback{}
+	if err := json.Unmarshal(e.Data.Object, cb); err != nil {
+		return nil, err
+	}
+	return cb, nil
+}
+
+// Subscription decodes the subscription included in a 'subscription.*' event
+func (e *Event) Subscription() (*Subscription, error) {
+	if !strings.HasPrefix(e.Type, "subscription.") {
+		return nil, errors.New("not a subscription event: " + e.Type)
+	}
+	sub := &Subscription{}
+	if err := json.Unmarshal(e.Data.Object, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
 }
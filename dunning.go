@@ -0,0 +1,301 @@
+package conekta
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Subscription events handled by the dunning process
+const (
+	EventSubscriptionPaymentFailed = "subscription.payment_failed"
+	EventSubscriptionPaid          = "subscription.paid"
+	EventSubscriptionCanceled      = "subscription.canceled"
+)
+
+// ErrDunningConflict is returned by 'DunningStore.Save' when the stored state
+// was modified after it was read
+var ErrDunningConflict = errors.New("dunning state modified concurrently")
+
+// Maximum attempts to apply an update when the state is modified concurrently
+const dunningRetries = 3
+
+// Actions available on a dunning schedule
+const (
+	// Notify the customer about the failed payment
+	DunningNotify = "notify"
+
+	// Pause the subscription
+	DunningPause = "pause"
+
+	// Cancel the subscription, ending the dunning process
+	DunningCancel = "cancel"
+)
+
+// A step on a dunning schedule
+type DunningStep struct {
+	// Time elapsed since the first failed payment before running the step
+	After time.Duration
+
+	// Action to perform
+	Action string
+}
+
+// Persistent state of a subscription in the dunning process
+type DunningState struct {
+	// Customer that owns the subscription
+	CustomerID string
+
+	// Subscription with failed payments
+	SubscriptionID string
+
+	// Number of failed payments observed
+	Failures uint
+
+	// Date of the first failed payment
+	FirstFailure time.Time
+
+	// Date of the latest failed payment
+	LastFailure time.Time
+
+	// Index of the next step on the schedule
+	Step int
+
+	// Date when the next step is due
+	NextAction time.Time
+
+	// Revision of the stored state, maintained by the store. Zero for states
+	// not saved yet
+	Version uint64
+}
+
+// DunningStore persists the state of the dunning process. Implementations
+// must be safe for concurrent use
+type DunningStore interface {
+	// Return the state of a subscription, 'nil' if not found
+	Get(ctx context.Context, subscriptionID string) (*DunningState, error)
+
+	// Create or update the state of a subscription. The update must only be
+	// applied if the stored version matches 'state.Version', otherwise
+	// 'ErrDunningConflict' is returned. On success 'state.Version' is
+	// incremented to match the stored revision
+	Save(ctx context.Context, state *DunningState) error
+
+	// Remove the state of a subscription
+	Delete(ctx context.Context, subscriptionID string) error
+
+	// Return all states with a step due at or before the provided date
+	Due(ctx context.Context, now time.Time) ([]*DunningState, error)
+}
+
+// Dunning reacts to failed subscription payments following a configurable
+// schedule of notifications, pausing or canceling the subscription at the end
+// of it. Feed subscription events with 'HandleEvent' and run 'Process'
+// periodically to execute due steps
+type Dunning struct {
+	// Used to pause and cancel subscriptions, usually 'Client.Subscriptions'
+	Subscriptions SubscriptionsAPI
+
+	// Persistent storage for the process state
+	Store DunningStore
+
+	// Steps to execute, relative to the first failed payment
+	Steps []DunningStep
+
+	// Executed for every 'notify' step
+	Notify func(ctx context.Context, state *DunningState) error
+}
+
+// DefaultDunningSteps returns a schedule notifying the customer right away and
+// after 3 and 7 days, and canceling the subscription after 14 days
+func DefaultDunningSteps() []DunningStep {
+	return []DunningStep{
+		{After: 0, Action: DunningNotify},
+		{After: 3 * 24 * time.Hour, Action: DunningNotify},
+		{After: 7 * 24 * time.Hour, Action: DunningNotify},
+		{After: 14 * 24 * time.Hour, Action: DunningCancel},
+	}
+}
+
+// HandleEvent updates the dunning process with a subscription event. Failed
+// payments start or continue the process, successful payments and
+// cancellations end it. Other events are ignored
+func (d *Dunning) HandleEvent(ctx context.Context, e *Event) error {
+	switch e.Type {
+	case EventSubscriptionPaymentFailed, EventSubscriptionPaid, EventSubscriptionCanceled:
+	default:
+		return nil
+	}
+	sub, err := e.Subscription()
+	if err != nil {
+		return err
+	}
+	if sub.ID == "" {
+		return errors.New("event without subscription id")
+	}
+	if e.Type != EventSubscriptionPaymentFailed {
+		return d.Store.Delete(ctx, sub.ID)
+	}
+
+	at := time.Now().UTC()
+	if e.CreatedAt > 0 {
+		at = unixTime(e.CreatedAt)
+	}
+	for i := 0; i < dunningRetries; i++ {
+		state, err := d.Store.Get(ctx, sub.ID)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			state = &DunningState{
+				CustomerID:     sub.CustomerID,
+				SubscriptionID: sub.ID,
+				FirstFailure:   at,
+			}
+			d.schedule(state)
+		}
+		state.Failures++
+		state.LastFailure = at
+		if err := d.Store.Save(ctx, state); !errors.Is(err, ErrDunningConflict) {
+			return err
+		}
+	}
+	return ErrDunningConflict
+}
+
+// Process executes all the steps due at the provided date. A failure on a
+// subscription doesn't prevent processing the remaining ones, all the errors
+// encountered are returned
+func (d *Dunning) Process(ctx context.Context, now time.Time) error {
+	due, err := d.Store.Due(ctx, now)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, state := range due {
+		if err := d.advance(ctx, state, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Execute the due steps of a single subscription. The state is saved after
+// every step completed, so steps are not repeated if a later one fails. If the
+// state is modified concurrently, for example by a new failed payment, the
+// step is recorded on the latest revision; processing stops if the state was
+// removed or already advanced elsewhere
+func (d *Dunning) advance(ctx context.Context, state *DunningState, now time.Time) error {
+	for state.Step < len(d.Steps) && !now.Before(state.NextAction) {
+		switch step := d.Steps[state.Step]; step.Action {
+		case DunningNotify:
+			if d.Notify != nil {
+				if err := d.Notify(ctx, state); err != nil {
+					return err
+				}
+			}
+		case DunningPause:
+			if _, err := d.Subscriptions.Pause(state.CustomerID, state.SubscriptionID); err != nil {
+				return err
+			}
+		case DunningCancel:
+			if _, err := d.Subscriptions.Cancel(state.CustomerID, state.SubscriptionID); err != nil {
+				return err
+			}
+			return d.Store.Delete(ctx, state.SubscriptionID)
+		default:
+			return errors.New("invalid dunning action: " + step.Action)
+		}
+		if state.Step+1 >= len(d.Steps) {
+			return d.Store.Delete(ctx, state.SubscriptionID)
+		}
+		next, err := d.completeStep(ctx, state)
+		if next == nil || err != nil {
+			return err
+		}
+		state = next
+	}
+	return nil
+}
+
+// Record the current step of the state as completed. Returns the saved state,
+// or 'nil' if it was removed or advanced concurrently
+func (d *Dunning) completeStep(ctx context.Context, state *DunningState) (*DunningState, error) {
+	step := state.Step
+	for i := 0; i < dunningRetries; i++ {
+		state.Step = step + 1
+		d.schedule(state)
+		err := d.Store.Save(ctx, state)
+		if !errors.Is(err, ErrDunningConflict) {
+			return state, err
+		}
+		if state, err = d.Store.Get(ctx, state.SubscriptionID); err != nil {
+			return nil, err
+		}
+		if state == nil || state.Step != step {
+			return nil, nil
+		}
+	}
+	return nil, ErrDunningConflict
+}
+
+// Calculate the due date of the next step
+func (d *Dunning) schedule(state *DunningState) {
+	if state.Step < len(d.Steps) {
+		state.NextAction = state.FirstFailure.Add(d.Steps[state.Step].After)
+	}
+}
+
+// In-memory dunning store, useful for tests and single instance deployments
+type memoryDunningStore struct {
+	mu     sync.Mutex
+	states map[string]DunningState
+}
+
+// NewMemoryDunningStore returns a dunning store that keeps its state in memory
+func NewMemoryDunningStore() DunningStore {
+	return &memoryDunningStore{states: make(map[string]DunningState)}
+}
+
+func (ms *memoryDunningStore) Get(ctx context.Context, subscriptionID string) (*DunningState, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if s, ok := ms.states[subscriptionID]; ok {
+		return &s, nil
+	}
+	return nil, nil
+}
+
+func (ms *memoryDunningStore) Save(ctx context.Context, state *DunningState) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.states[state.SubscriptionID].Version != state.Version {
+		return ErrDunningConflict
+	}
+	state.Version++
+	ms.states[state.SubscriptionID] = *state
+	return nil
+}
+
+func (ms *memoryDunningStore) Delete(ctx context.Context, subscriptionID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.states, subscriptionID)
+	return nil
+}
+
+func (ms *memoryDunningStore) Due(ctx context.Context, now time.Time) ([]*DunningState, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var list []*DunningState
+	for _, s := range ms.states {
+		if !now.Before(s.NextAction) {
+			s := s
+			list = append(list, &s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].NextAction.Before(list[j].NextAction) })
+	return list, nil
+}
@@ -0,0 +1,190 @@
+package conekta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Records the subscription operations performed by the dunning process
+type fakeSubscriptions struct {
+	SubscriptionsAPI
+	paused   []string
+	canceled []string
+	fail     map[string]bool
+}
+
+func (fs *fakeSubscriptions) Pause(customerID, subscriptionID string) (*Subscription, error) {
+	if fs.fail[subscriptionID] {
+		return nil, errors.New("service unavailable")
+	}
+	fs.paused = append(fs.paused, subscriptionID)
+	return &Subscription{ID: subscriptionID, Status: SubscriptionPaused}, nil
+}
+
+func (fs *fakeSubscriptions) Cancel(customerID, subscriptionID string) (*Subscription, error) {
+	fs.canceled = append(fs.canceled, subscriptionID)
+	return &Subscription{ID: subscriptionID, Status: SubscriptionCanceled}, nil
+}
+
+// Runs 'race' right before the next save, simulating a concurrent update
+type racingStore struct {
+	DunningStore
+	race func()
+}
+
+func (rs *racingStore) Save(ctx context.Context, state *DunningState) error {
+	if race := rs.race; race != nil {
+		rs.race = nil
+		race()
+	}
+	return rs.DunningStore.Save(ctx, state)
+}
+
+// Return a subscription event for the provided subscription
+func subscriptionEvent(subscriptionID, kind string, at time.Time) *Event {
+	obj, _ := json.Marshal(&Subscription{ID: subscriptionID, CustomerID: "cus_1", Status: SubscriptionPastDue})
+	return &Event{Type: kind, CreatedAt: uint32(at.Unix()), Data: EventData{Object: obj}}
+}
+
+func TestDunning(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	event := func(kind string, at time.Time) *Event {
+		return subscriptionEvent("sub_1", kind, at)
+	}
+
+	subs := &fakeSubscriptions{}
+	notified := 0
+	d := &Dunning{
+		Subscriptions: subs,
+		Store:         NewMemoryDunningStore(),
+		Steps: []DunningStep{
+			{After: 0, Action: DunningNotify},
+			{After: 48 * time.Hour, Action: DunningPause},
+			{After: 96 * time.Hour, Action: DunningCancel},
+		},
+		Notify: func(ctx context.Context, state *DunningState) error {
+			notified++
+			return nil
+		},
+	}
+
+	t.Run("Failure", func(t *testing.T) {
+		if err := d.HandleEvent(ctx, event(EventSubscriptionPaymentFailed, start)); err != nil {
+			t.Fatal(err)
+		}
+		d.HandleEvent(ctx, event(EventSubscriptionPaymentFailed, start.Add(time.Hour)))
+		state, _ := d.Store.Get(ctx, "sub_1")
+		if state == nil || state.Failures != 2 || !state.FirstFailure.Equal(start) {
+			t.Fatalf("invalid dunning state: %+v", state)
+		}
+	})
+
+	t.Run("Schedule", func(t *testing.T) {
+		d.Process(ctx, start.Add(time.Hour))
+		if notified != 1 || len(subs.paused) != 0 {
+			t.Fatal("invalid first step")
+		}
+		d.Process(ctx, start.Add(50*time.Hour))
+		if len(subs.paused) != 1 || len(subs.canceled) != 0 {
+			t.Fatal("subscription should be paused")
+		}
+		d.Process(ctx, start.Add(100*time.Hour))
+		if len(subs.canceled) != 1 {
+			t.Fatal("subscription should be canceled")
+		}
+		if state, _ := d.Store.Get(ctx, "sub_1"); state != nil {
+			t.Error("state should be removed at the end of the schedule")
+		}
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		d.HandleEvent(ctx, event(EventSubscriptionPaymentFailed, start))
+		d.HandleEvent(ctx, event(EventSubscriptionPaid, start.Add(time.Hour)))
+		if state, _ := d.Store.Get(ctx, "sub_1"); state != nil {
+			t.Error("state should be removed after a successful payment")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		subs := &fakeSubscriptions{fail: map[string]bool{"sub_a": true}}
+		notified := map[string]int{}
+		d := &Dunning{
+			Subscriptions: subs,
+			Store:         NewMemoryDunningStore(),
+			Steps: []DunningStep{
+				{After: 0, Action: DunningNotify},
+				{After: time.Hour, Action: DunningPause},
+			},
+			Notify: func(ctx context.Context, state *DunningState) error {
+				notified[state.SubscriptionID]++
+				return nil
+			},
+		}
+		d.HandleEvent(ctx, subscriptionEvent("sub_a", EventSubscriptionPaymentFailed, start))
+		d.HandleEvent(ctx, subscriptionEvent("sub_b", EventSubscriptionPaymentFailed, start))
+
+		if err := d.Process(ctx, start.Add(2*time.Hour)); err == nil {
+			t.Fatal("failed to report step error")
+		}
+		if len(subs.paused) != 1 || subs.paused[0] != "sub_b" {
+			t.Error("remaining subscriptions should be processed after a failure")
+		}
+		d.Process(ctx, start.Add(3*time.Hour))
+		if notified["sub_a"] != 1 || notified["sub_b"] != 1 {
+			t.Errorf("completed steps should not be repeated: %v", notified)
+		}
+		if state, _ := d.Store.Get(ctx, "sub_a"); state == nil || state.Step != 1 {
+			t.Error("failed step should be retried")
+		}
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		store := &racingStore{DunningStore: NewMemoryDunningStore()}
+		d := &Dunning{
+			Subscriptions: &fakeSubscriptions{},
+			Store:         store,
+			Steps: []DunningStep{
+				{After: 0, Action: DunningNotify},
+				{After: time.Hour, Action: DunningNotify},
+				{After: 2 * time.Hour, Action: DunningCancel},
+			},
+		}
+		store.race = func() {
+			d.HandleEvent(ctx, subscriptionEvent("sub_c", EventSubscriptionPaymentFailed, start))
+		}
+		d.HandleEvent(ctx, subscriptionEvent("sub_c", EventSubscriptionPaymentFailed, start))
+		if state, _ := store.Get(ctx, "sub_c"); state == nil || state.Failures != 2 {
+			t.Fatalf("concurrent failures should not be lost: %+v", state)
+		}
+
+		store.race = func() {
+			d.HandleEvent(ctx, subscriptionEvent("sub_c", EventSubscriptionPaymentFailed, start.Add(time.Minute)))
+		}
+		if err := d.Process(ctx, start); err != nil {
+			t.Fatal(err)
+		}
+		state, _ := store.Get(ctx, "sub_c")
+		if state == nil || state.Failures != 3 || state.Step != 1 {
+			t.Errorf("step and failures should both be recorded: %+v", state)
+		}
+
+		store.race = func() {
+			d.HandleEvent(ctx, subscriptionEvent("sub_c", EventSubscriptionPaid, start.Add(time.Hour)))
+		}
+		if err := d.Process(ctx, start.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if state, _ := store.Get(ctx, "sub_c"); state != nil {
+			t.Errorf("recovered subscription should not be restored: %+v", state)
+		}
+
+		store.Save(ctx, &DunningState{SubscriptionID: "sub_c"})
+		if err := store.Save(ctx, &DunningState{SubscriptionID: "sub_c"}); !errors.Is(err, ErrDunningConflict) {
+			t.Error("failed to detect stale state")
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package conekta
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RFC structure: 3 (companies) or 4 (individuals) letters, date of
+// incorporation or birth as YYMMDD and a 3 characters homoclave
+var rfcPattern = regexp.MustCompile(`^[A-ZÑ&]{3,4}(\d{2})(\d{2})(\d{2})[A-Z\d]{2}[A\d]$`)
+
+// ValidateRFC verifies the provided value is a well formed RFC (Registro
+// Federal de Contribuyentes), the tax ID used in Mexico. Only the format and
+// embedded date are verified, not its registration status
+func ValidateRFC(rfc string) error {
+	m := rfcPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(rfc)))
+	if m == nil {
+		return errors.New("invalid RFC format")
+	}
+	yy, _ := strconv.Atoi(m[1])
+	mm, _ := strconv.Atoi(m[2])
+	dd, _ := strconv.Atoi(m[3])
+	d := time.Date(2000+yy, time.Month(mm), dd, 0, 0, 0, 0, time.UTC)
+	if d.Month() != time.Month(mm) || d.Day() != dd {
+		return errors.New("invalid RFC date")
+	}
+	return nil
+}
+
+// Verify the tax ID of a Mexican fiscal entity
+func validateFiscalEntity(entity *FiscalEntity) error {
+	if entity.TaxID == "" {
+		return errors.New("fiscal entity tax ID is required")
+	}
+	country := strings.ToUpper(entity.Address.Country)
+	if country == "" || country == "MX" {
+		return ValidateRFC(entity.TaxID)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package conekta
+
+import "testing"
+
+func TestValidateRFC(t *testing.T) {
+	valid := []string{
+		"XAXX010101000",
+		"ABC680524P76",
+		"GODE561231GR8",
+		"aaa000229a12",
+		"&ÑA991231AB1",
+	}
+	for _, rfc := range valid {
+		if err := ValidateRFC(rfc); err != nil {
+			t.Errorf("%s: %s", rfc, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"AB680524P76",
+		"GODE561231GR",
+		"GODE561331GR8",
+		"GODE010229GR8",
+		"GODE56123-GR8",
+	}
+	for _, rfc := range invalid {
+		if ValidateRFC(rfc) == nil {
+			t.Errorf("failed to detect invalid RFC: %s", rfc)
+		}
+	}
+
+	// Tax IDs from other countries are not validated as RFC
+	if validateFiscalEntity(&FiscalEntity{TaxID: "123-45-6789", Address: Address{Country: "US"}}) != nil {
+		t.Error("foreign tax ID should not be validated as RFC")
+	}
+}
+
+func TestOrderFiscalEntity(t *testing.T) {
+	orders := map[string]*Order{
+		"missing tax ID": {FiscalEntity: &FiscalEntity{Address: Address{Country: "MX"}}},
+		"invalid RFC":    {FiscalEntity: &FiscalEntity{TaxID: "GODE561331GR8"}},
+	}
+	for name, o := range orders {
+		if o.validate() == nil {
+			t.Errorf("%s: invalid fiscal entity accepted", name)
+		}
+	}
+
+	valid := []*Order{
+		{FiscalEntity: &FiscalEntity{TaxID: "XAXX010101000", Address: Address{Country: "MX"}}},
+		{FiscalEntity: &FiscalEntity{ID: "fis_1"}},
+		{},
+	}
+	for i, o := range valid {
+		if err := o.validate(); err != nil {
+			t.Errorf("order %d: %s", i, err)
+		}
+	}
+
+	// Orders are validated before being dispatched
+	rt := &recordingTransport{reply: `{}`}
+	client := recordingClient(t, rt)
+	if client.Orders.Create(orders["invalid RFC"]) == nil || len(rt.requests) != 0 {
+		t.Error("invalid fiscal entity should not be sent")
+	}
+}
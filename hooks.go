@@ -0,0 +1,202 @@
+package conekta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fairbank-io/conekta/card"
+)
+
+// Placeholder used to replace sensitive values before exposing request contents
+const redactedValue = "[REDACTED]"
+
+// Payload fields that may contain sensitive information and should never be
+// exposed to hooks or logs, along with the function used to mask their values
+var sensitiveFields = map[string]func(string) string{
+	"number":          card.Mask,
+	"cvc":             redact,
+	"token_id":        redact,
+	"email":           maskEmail,
+	"customer_email":  maskEmail,
+	"phone":           maskPhone,
+	"phonenumber":     maskPhone,
+	"street1":         redact,
+	"street2":         redact,
+	"postal_code":     redact,
+	"between_streets": redact,
+}
+
+// Details about a request about to be dispatched to the service
+type RequestInfo struct {
+	// Context the request is bound to. Hooks executed before the request may
+	// replace it, for example to attach tracing information, the new value will
+	// be used to dispatch the request
+	Context context.Context
+
+	// Name of the API operation, for example 'orders.create'
+	Operation string
+
+	// HTTP method used
+	Method string
+
+	// Full URL of the request with query values, other than pagination
+	// parameters, redacted
+	Endpoint string
+
+	// JSON payload of the request with sensitive values redacted
+	Body []byte
+
+	// API version requested
+	APIVersion string
+}
+
+// Details about a completed request, including failed ones
+type ResponseInfo struct {
+	RequestInfo
+
+	// HTTP status code returned by the service, 0 on network level errors
+	Status int
+
+	// Total time spent on the request
+	Latency time.Duration
+
+	// The id of the http log of the request, as reported by the service on errors
+	LogID string
+
+	// Identifier of the resource returned by the service, if any
+	ResourceID string
+
+	// Error returned to the caller, if any
+	Err error
+}
+
+// Hooks allow to inspect all the requests performed by a client, for example
+// for logging or auditing purposes. Hooks are executed synchronously, any long
+// running operation should be handled on a separate goroutine
+type Hooks struct {
+	// Executed before a request is dispatched
+	BeforeRequest func(req *RequestInfo)
+
+	// Executed after a request is completed
+	AfterRequest func(res *ResponseInfo)
+}
+
+// ChainHooks combines several hooks into a single one, executing them in the
+// order provided
+func ChainHooks(hooks ...*Hooks) *Hooks {
+	return &Hooks{
+		BeforeRequest: func(req *RequestInfo) {
+			for _, h := range hooks {
+				if h != nil && h.BeforeRequest != nil {
+					h.BeforeRequest(req)
+				}
+			}
+		},
+		AfterRequest: func(res *ResponseInfo) {
+			for _, h := range hooks {
+				if h != nil && h.AfterRequest != nil {
+					h.AfterRequest(res)
+				}
+			}
+		},
+	}
+}
+
+// LogHooks returns hooks that report every request to the provided logger.
+// Outgoing requests are logged at debug level, completed requests at info level
+// and failed requests at error level
+func LogHooks(logger *slog.Logger) *Hooks {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Hooks{
+		BeforeRequest: func(req *RequestInfo) {
+			logger.DebugContext(req.Context, "conekta request",
+				slog.String("operation", req.Operation),
+				slog.String("method", req.Method),
+				slog.String("endpoint", req.Endpoint),
+				slog.String("body", string(req.Body)))
+		},
+		AfterRequest: func(res *ResponseInfo) {
+			attrs := []slog.Attr{
+				slog.String("operation", res.Operation),
+				slog.String("method", res.Method),
+				slog.String("endpoint", res.Endpoint),
+				slog.Int("status", res.Status),
+				slog.Duration("latency", res.Latency),
+			}
+			if res.LogID != "" {
+				attrs = append(attrs, slog.String("log_id", res.LogID))
+			}
+			if res.Err != nil {
+				attrs = append(attrs, slog.Any("error", res.Err))
+				logger.LogAttrs(res.Context, slog.LevelError, "conekta request failed", attrs...)
+				return
+			}
+			logger.LogAttrs(res.Context, slog.LevelInfo, "conekta response", attrs...)
+		},
+	}
+}
+
+// Query parameters safe to be exposed, all other values are redacted since
+// filters like 'search' may include contact details
+var safeQueryParams = map[string]bool{
+	"limit":    true,
+	"next":     true,
+	"previous": true,
+}
+
+// Returns the provided URL with all sensitive query values replaced
+func redactURL(endpoint string) string {
+	base, query, found := strings.Cut(endpoint, "?")
+	if !found {
+		return endpoint
+	}
+	params := strings.Split(query, "&")
+	for i, p := range params {
+		k, _, _ := strings.Cut(p, "=")
+		if !safeQueryParams[k] {
+			params[i] = k + "=" + redactedValue
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+// Returns a copy of the provided JSON payload with all sensitive values replaced
+func redactJSON(data []byte) []byte {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil
+	}
+	b, _ := json.Marshal(redactValue(v))
+	return b
+}
+
+// Recursively walk a decoded JSON value replacing sensitive fields
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if mask, ok := sensitiveFields[strings.ToLower(k)]; ok {
+				if str, ok := item.(string); ok {
+					val[k] = mask(str)
+				} else {
+					val[k] = redactedValue
+				}
+				continue
+			}
+			val[k] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+	}
+	return v
+}
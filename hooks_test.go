@@ -0,0 +1,210 @@
+package conekta
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type hookKey struct{}
+
+func TestHooks(t *testing.T) {
+	var reqs []*RequestInfo
+	var res []*ResponseInfo
+	var calls []string
+	hooks := ChainHooks(
+		&Hooks{
+			BeforeRequest: func(req *RequestInfo) {
+				calls = append(calls, "first")
+				req.Context = context.WithValue(req.Context, hookKey{}, "traced")
+				reqs = append(reqs, req)
+			},
+			AfterRequest: func(r *ResponseInfo) {
+				res = append(res, r)
+			},
+		},
+		nil,
+		&Hooks{BeforeRequest: func(req *RequestInfo) { calls = append(calls, "second") }},
+	)
+	rt := &recordingTransport{reply: `{"id": "ord_1", "object": "order"}`}
+	client, err := NewClient("key_test", &Options{Hooks: hooks})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+
+	t.Run("Chain", func(t *testing.T) {
+		client.Orders.Get("ord_1")
+		if strings.Join(calls, ",") != "first,second" {
+			t.Errorf("hooks executed out of order: %v", calls)
+		}
+	})
+
+	t.Run("Request", func(t *testing.T) {
+		if rt.requests[0].Context().Value(hookKey{}) != "traced" {
+			t.Error("context provided by hooks should be used to dispatch the request")
+		}
+		r := res[0]
+		if r.Operation != "orders.get" || r.Method != http.MethodGet || r.APIVersion != "v2.0.0" {
+			t.Errorf("invalid request details: %+v", r.RequestInfo)
+		}
+		if r.Status != http.StatusOK || r.ResourceID != "ord_1" || r.Err != nil {
+			t.Errorf("invalid response details: %+v", r)
+		}
+	})
+
+	t.Run("Body", func(t *testing.T) {
+		client.Customers.Create(&Customer{Name: "Rick Sanchez", Email: "rick@citadel.com"})
+		body := string(reqs[len(reqs)-1].Body)
+		if strings.Contains(body, "rick@citadel.com") || !strings.Contains(body, "Rick Sanchez") {
+			t.Errorf("invalid redacted body: %s", body)
+		}
+		if !strings.Contains(rt.bodies[len(rt.bodies)-1], "rick@citadel.com") {
+			t.Error("redaction should not modify the payload sent")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rt.status = http.StatusPaymentRequired
+		rt.reply = `{"type": "processing_error", "log_id": "log_1"}`
+		defer func() { rt.status, rt.reply = 0, `{}` }()
+		client.Orders.Get("ord_2")
+		r := res[len(res)-1]
+		if r.Status != http.StatusPaymentRequired || r.LogID != "log_1" || r.Err == nil || r.ResourceID != "" {
+			t.Errorf("invalid failed response details: %+v", r)
+		}
+	})
+}
+
+func TestLogHooks(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rt := &recordingTransport{reply: `{"id": "cus_1"}`}
+	client, err := NewClient("key_test", &Options{Hooks: LogHooks(logger)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+
+	client.Customers.Create(&Customer{Name: "Rick Sanchez", Phone: "+525511223344"})
+	rt.status = http.StatusBadRequest
+	rt.reply = `{"type": "parameter_validation_error", "log_id": "log_1", "details": [{"debug_message": "invalid +525511223344", "code": "invalid_phone"}]}`
+	client.Customers.Create(&Customer{Name: "Rick Sanchez", Phone: "+525511223344"})
+
+	out := buf.String()
+	for _, v := range []string{"level=DEBUG msg=\"conekta request\"", "level=INFO msg=\"conekta response\"",
+		"level=ERROR msg=\"conekta request failed\"", "operation=customers.create", "log_id=log_1", "invalid_phone"} {
+		if !strings.Contains(out, v) {
+			t.Errorf("expected '%s' on log output: %s", v, out)
+		}
+	}
+	if strings.Contains(out, "+525511223344") {
+		t.Errorf("sensitive value logged: %s", out)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	rt := &recordingTransport{reply: `{"object": "list", "has_more": false, "data": []}`}
+	var endpoints []string
+	client, err := NewClient("key_test", &Options{Hooks: &Hooks{
+		BeforeRequest: func(req *RequestInfo) { endpoints = append(endpoints, req.Endpoint) },
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+
+	client.Customers.Search("rick@citadel.com")
+	if endpoints[0] != "https://api.conekta.io/customers?limit=250&search=[REDACTED]" {
+		t.Errorf("invalid redacted endpoint: %s", endpoints[0])
+	}
+	if rt.requests[0].URL.Query().Get("search") != "rick@citadel.com" {
+		t.Error("redaction should not modify the request sent")
+	}
+	if redactURL("https://api.conekta.io/orders/ord_1") != "https://api.conekta.io/orders/ord_1" {
+		t.Error("endpoints without query should not be modified")
+	}
+}
+
+func TestHooksRedaction(t *testing.T) {
+	const (
+		pan    = "4242424242424242"
+		email  = "rick@citadel.com"
+		phone  = "+525511223344"
+		street = "calle 6 910"
+		postal = "94510"
+		cvc    = "987"
+		token  = "tok_secret"
+	)
+	address := Address{Street1: street, Street2: street, PostalCode: postal, City: "Cordoba", Country: "MX"}
+	contact := ShippingContact{ID: "ship_1", Phone: phone, BetweenStreets: street, Address: address}
+	info := CustomerInfo{Name: "Rick Sanchez", Email: email, Phone: phone}
+	entity := FiscalEntity{ID: "fis_1", TaxID: "XAXX010101000", Email: email, Phone: phone, Address: address}
+	method := Card{Type: "card", Number: pan, ExpMonth: "12", ExpYear: "2099", TokenID: token}
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var bodies []string
+	hooks := ChainHooks(LogHooks(logger), &Hooks{
+		BeforeRequest: func(req *RequestInfo) { bodies = append(bodies, string(req.Body)+" "+req.Endpoint) },
+	})
+	rt := &recordingTransport{reply: `{}`}
+	client, err := NewClient("key_test", &Options{Hooks: hooks, PublicKey: "key_public"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+
+	calls := map[string]func(){
+		"orders.create": func() {
+			client.Orders.Create(&Order{CustomerInfo: info, ShippingContact: contact, FiscalEntity: &entity,
+				Charges: []Charge{{PaymentMethod: method}}})
+		},
+		"orders.update":        func() { client.Orders.Update(&Order{ID: "ord_1", CustomerInfo: info, ShippingContact: contact}) },
+		"orders.create_charge": func() { client.Orders.CreateCharge("ord_1", &Charge{PaymentMethod: method}) },
+		"customers.create": func() {
+			client.Customers.Create(&Customer{Email: email, Phone: phone, ShippingContacts: []ShippingContact{contact}})
+		},
+		"customers.update":       func() { client.Customers.Update(&Customer{ID: "cus_1", Email: email, Phone: phone}) },
+		"customers.search":       func() { client.Customers.Search(email) },
+		"payment_sources.create": func() { client.Customers.CreatePaymentSource("cus_1", token) },
+		"payment_sources.add":    func() { client.Customers.AddPaymentSource("cus_1", &PaymentSourceParams{TokenID: token}) },
+		"payment_sources.update": func() {
+			client.Customers.UpdatePaymentSource("cus_1", &PaymentSourceUpdate{ID: "src_1", Address: address})
+		},
+		"shipping_contacts.create": func() { client.Customers.CreateShippingContact("cus_1", &contact) },
+		"shipping_contacts.update": func() { client.Customers.UpdateShippingContact("cus_1", &contact) },
+		"fiscal_entities.create":   func() { client.Customers.CreateFiscalEntity("cus_1", &entity) },
+		"fiscal_entities.update":   func() { client.Customers.UpdateFiscalEntity("cus_1", &entity) },
+		"checkouts.create": func() {
+			client.Checkouts.Create(&Checkout{OrderTemplate: &CheckoutOrderTemplate{Currency: "MXN", CustomerInfo: &info}})
+		},
+		"checkouts.send_email": func() { client.Checkouts.SendEmail("chk_1", email) },
+		"checkouts.send_sms":   func() { client.Checkouts.SendSMS("chk_1", phone) },
+		"tokens.create":        func() { client.Tokens.Create(&method, cvc) },
+		"chargebacks.submit_evidence": func() {
+			client.Chargebacks.SubmitEvidence("cbk_1", &ChargebackEvidence{CustomerName: "Rick Sanchez", CustomerEmail: email})
+		},
+	}
+	for name, call := range calls {
+		bodies = nil
+		call()
+		if len(bodies) == 0 {
+			t.Errorf("%s: no request dispatched", name)
+			continue
+		}
+		for _, v := range []string{pan, email, phone, street, postal, `"` + cvc + `"`, token} {
+			if strings.Contains(bodies[0], v) {
+				t.Errorf("%s: sensitive value exposed to hooks: %s", name, bodies[0])
+			}
+		}
+	}
+	for _, v := range []string{pan, email, phone, street, postal, token} {
+		if strings.Contains(buf.String(), v) {
+			t.Errorf("sensitive value logged: %s", v)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package conekta
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Customer fields available to identify customers
+const (
+	IdentityEmail    = "email"
+	IdentityPhone    = "phone"
+	IdentityMetadata = "metadata"
+)
+
+// CustomerIdentity describes how to identify a customer when looking for
+// duplicates
+type CustomerIdentity struct {
+	// Customer field used: email, phone or metadata
+	Field string
+
+	// Metadata key holding the identity value, usually an external ID assigned
+	// by the caller. Required when using metadata
+	MetadataKey string
+}
+
+// ByEmail identifies customers by email address, case insensitive
+var ByEmail = CustomerIdentity{Field: IdentityEmail}
+
+// ByPhone identifies customers by phone number, ignoring formatting characters
+var ByPhone = CustomerIdentity{Field: IdentityPhone}
+
+// ByMetadata identifies customers by the value stored under a metadata key
+func ByMetadata(key string) CustomerIdentity {
+	return CustomerIdentity{Field: IdentityMetadata, MetadataKey: key}
+}
+
+// Return the identity value of a customer, empty if it can't be identified
+func (ci CustomerIdentity) value(c *Customer) string {
+	switch ci.Field {
+	case IdentityEmail:
+		return strings.ToLower(strings.TrimSpace(c.Email))
+	case IdentityPhone:
+		return strings.Map(func(r rune) rune {
+			if (r >= '0' && r <= '9') || r == '+' {
+				return r
+			}
+			return -1
+		}, c.Phone)
+	case IdentityMetadata:
+		if ci.MetadataKey != "" {
+			return c.Metadata[ci.MetadataKey]
+		}
+	}
+	return ""
+}
+
+// Whether the identity is covered by the service search
+func (ci CustomerIdentity) searchable() bool {
+	return ci.Field == IdentityEmail || ci.Field == IdentityPhone
+}
+
+// Per key mutual exclusion, used to serialize operations on the same
+// identity within a client
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Acquire the lock for a key, returning the function to release it
+func (km *keyedMutex) lock(key string) func() {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = make(map[string]*keyedLock)
+	}
+	l, ok := km.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		km.locks[key] = l
+	}
+	l.refs++
+	km.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		km.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(km.locks, key)
+		}
+		km.mu.Unlock()
+	}
+}
+
+// Return the customers matching the provided identity value, oldest first
+func matchCustomers(list []Customer, identity CustomerIdentity, value string) []Customer {
+	var res []Customer
+	for i := range list {
+		if identity.value(&list[i]) == value {
+			res = append(res, list[i])
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].CreatedAt != res[j].CreatedAt {
+			return res[i].CreatedAt < res[j].CreatedAt
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
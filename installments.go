@@ -0,0 +1,155 @@
+package conekta
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Monthly installment terms supported by the service
+var InstallmentTerms = []uint32{3, 6, 9, 12}
+
+// Minimum order amount, in MXN cents, required for each installment term
+var InstallmentMinimums = map[uint32]uint32{
+	3:  30000,
+	6:  60000,
+	9:  90000,
+	12: 120000,
+}
+
+// Card types reported on BIN information
+const (
+	CardTypeCredit = "credit"
+	CardTypeDebit  = "debit"
+)
+
+// Information about the card issuer, identified by the first digits of the
+// card number (BIN), usually provided by a BIN lookup service
+type BINInfo struct {
+	// First 6 to 8 digits of the card number
+	BIN string
+
+	// Card brand: visa, mastercard, american_express or carnet
+	Brand string
+
+	// Card type: credit or debit
+	Type string
+
+	// Country of the issuer, ISO 3166-1 two-letter code
+	Country string
+
+	// Name of the issuing bank (optional)
+	Issuer string
+}
+
+// Details of the payments made when splitting an amount in monthly installments
+type InstallmentBreakdown struct {
+	// Number of monthly payments
+	Term uint32
+
+	// Total amount, in cents
+	Amount uint32
+
+	// Amount of each monthly payment, in cents
+	Monthly uint32
+
+	// Amount of the first payment, in cents. It includes the remainder when the
+	// amount is not evenly divisible by the term
+	First uint32
+}
+
+// Installments returns the payment breakdown of an amount, in cents, split in
+// the provided number of monthly installments
+func Installments(amount, term uint32) (*InstallmentBreakdown, error) {
+	if _, ok := InstallmentMinimums[term]; !ok {
+		return nil, fmt.Errorf("unsupported installments term: %d", term)
+	}
+	return &InstallmentBreakdown{
+		Term:    term,
+		Amount:  amount,
+		Monthly: amount / term,
+		First:   amount/term + amount%term,
+	}, nil
+}
+
+// ValidateInstallments verifies a charge of the provided amount, in cents,
+// can be paid in the requested number of monthly installments. Installments
+// are only available for MXN charges made with credit cards issued in
+// Mexico. If no BIN information is provided the card is not verified
+func ValidateInstallments(amount uint32, currency string, term uint32, bin *BINInfo) error {
+	minimum, ok := InstallmentMinimums[term]
+	if !ok {
+		return fmt.Errorf("unsupported installments term: %d", term)
+	}
+	if !strings.EqualFold(currency, "MXN") {
+		return errors.New("installments are only available for MXN charges")
+	}
+	if amount < minimum {
+		return fmt.Errorf("amount %d is below the minimum of %d for %d installments", amount, minimum, term)
+	}
+	if bin == nil {
+		return nil
+	}
+	if !strings.EqualFold(bin.Type, CardTypeCredit) {
+		return errors.New("installments require a credit card")
+	}
+	if !strings.EqualFold(bin.Country, "MX") {
+		return errors.New("installments require a card issued in Mexico")
+	}
+	return nil
+}
+
+// EligibleInstallments returns the installment terms available for a charge of
+// the provided amount, in cents, and card
+func EligibleInstallments(amount uint32, currency string, bin *BINInfo) []uint32 {
+	var terms []uint32
+	for _, term := range InstallmentTerms {
+		if ValidateInstallments(amount, currency, term, bin) == nil {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Total returns the order amount calculated locally from its line items,
+// shipping lines, tax lines and discount lines, in cents
+func (o *Order) Total() uint32 {
+	var total int64
+	for _, li := range o.LineItems {
+		total += int64(li.UnitPrice) * int64(li.Quantity)
+	}
+	for _, sl := range o.ShippingLines {
+		total += int64(sl.Amount)
+	}
+	for _, tl := range o.TaxLines {
+		total += int64(tl.Amount)
+	}
+	for _, dl := range o.DiscountLines {
+		total -= int64(dl.Amount)
+	}
+	if total < 0 {
+		return 0
+	}
+	return uint32(total)
+}
+
+// SetInstallments configures the card charges of the order to be paid in the
+// provided number of monthly installments, after verifying the order total
+// and card are eligible. The resulting payment breakdown is returned
+func (o *Order) SetInstallments(term uint32, bin *BINInfo) (*InstallmentBreakdown, error) {
+	total := o.Total()
+	if err := ValidateInstallments(total, o.Currency, term, bin); err != nil {
+		return nil, err
+	}
+	found := false
+	for i := range o.Charges {
+		if o.Charges[i].PaymentMethod.Type == PaymentSourceCard {
+			o.Charges[i].MonthlyInstallments = term
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.New("installments require a card charge")
+	}
+	return Installments(total, term)
+}
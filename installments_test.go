@@ -0,0 +1,57 @@
+package conekta
+
+import "testing"
+
+func TestInstallments(t *testing.T) {
+	credit := &BINInfo{BIN: "415231", Brand: "visa", Type: CardTypeCredit, Country: "MX"}
+
+	t.Run("Breakdown", func(t *testing.T) {
+		b, err := Installments(100000, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Monthly != 33333 || b.First != 33334 || b.First+b.Monthly*2 != b.Amount {
+			t.Errorf("invalid breakdown: %+v", b)
+		}
+		if _, err := Installments(100000, 4); err == nil {
+			t.Error("failed to detect invalid term")
+		}
+	})
+
+	t.Run("Eligibility", func(t *testing.T) {
+		terms := EligibleInstallments(95000, "mxn", credit)
+		if len(terms) != 3 || terms[2] != 9 {
+			t.Errorf("invalid eligible terms: %v", terms)
+		}
+		if len(EligibleInstallments(95000, "USD", credit)) != 0 {
+			t.Error("installments should require MXN")
+		}
+		debit := &BINInfo{Type: CardTypeDebit, Country: "MX"}
+		if ValidateInstallments(95000, "MXN", 3, debit) == nil {
+			t.Error("installments should require a credit card")
+		}
+		foreign := &BINInfo{Type: CardTypeCredit, Country: "US"}
+		if ValidateInstallments(95000, "MXN", 3, foreign) == nil {
+			t.Error("installments should require a Mexican card")
+		}
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		order := &Order{
+			Currency:      "MXN",
+			LineItems:     []LineItem{{UnitPrice: 40000, Quantity: 2}},
+			DiscountLines: []DiscountLine{{Amount: 10000}},
+			Charges:       []Charge{{PaymentMethod: Card{Type: "card"}}},
+		}
+		b, err := order.SetInstallments(6, credit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Amount != 70000 || order.Charges[0].MonthlyInstallments != 6 {
+			t.Error("failed to configure order installments")
+		}
+		if _, err := order.SetInstallments(9, credit); err == nil {
+			t.Error("failed to detect amount below minimum")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package conekta
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Maximum number of elements the service returns per page
+const maxPageSize = 250
+
+// Pagination parameters for list operations
+// https://developers.conekta.com/api?language=bash#pagination
+type ListOptions struct {
+	// Maximum number of elements to return, up to 250
+	Limit uint
+
+	// Return the elements after the one with this ID
+	Next string
+
+	// Return the elements before the one with this ID
+	Previous string
+
+	// Additional filters supported by the endpoint
+	Filters map[string]string
+}
+
+// Encode the options as query parameters
+func (lo *ListOptions) query() string {
+	v := url.Values{}
+	if lo.Limit > 0 {
+		v.Set("limit", strconv.Itoa(int(lo.Limit)))
+	}
+	if lo.Next != "" {
+		v.Set("next", lo.Next)
+	}
+	if lo.Previous != "" {
+		v.Set("previous", lo.Previous)
+	}
+	for k, f := range lo.Filters {
+		v.Set(k, f)
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// Envelope used by the service when returning a collection of resources
+// https://developers.conekta.com/api?language=bash#pagination
+type listResponse struct {
+	// Object class. In this case, "list"
+	Object string `json:"object"`
+
+	// Whether more elements are available after the ones returned
+	HasMore bool `json:"has_more"`
+
+	// Total number of elements in the collection
+	Total uint32 `json:"total"`
+
+	// Encoded collection elements
+	Data json.RawMessage `json:"data"`
+}
+
+// Retrieve the elements of a collection. If no options are provided all the
+// pages available are retrieved, otherwise only the page requested
+func listAll[T any](c *Client, op, endpoint string, opts *ListOptions) ([]T, error) {
+	var list []T
+	all := opts == nil
+	if all {
+		opts = &ListOptions{Limit: maxPageSize}
+	}
+	for {
+		b, err := c.request(&requestOptions{
+			op:       op,
+			endpoint: endpoint + opts.query(),
+			method:   http.MethodGet,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l := &listResponse{}
+		if err := json.Unmarshal(b, l); err != nil {
+			return nil, err
+		}
+		if len(l.Data) == 0 {
+			return list, nil
+		}
+		var items []T
+		if err := json.Unmarshal(l.Data, &items); err != nil {
+			return nil, err
+		}
+		list = append(list, items...)
+		if !all || !l.HasMore {
+			return list, nil
+		}
+
+		// Continue after the last element received
+		var ids []struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(l.Data, &ids)
+		if len(ids) == 0 {
+			return list, nil
+		}
+		opts = &ListOptions{Limit: opts.Limit, Filters: opts.Filters, Next: ids[len(ids)-1].ID}
+	}
+}
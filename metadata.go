@@ -0,0 +1,193 @@
+package conekta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Metadata limits enforced by the service
+const (
+	MaxMetadataKeys        = 100
+	MaxMetadataKeyLength   = 100
+	MaxMetadataValueLength = 500
+)
+
+// Metadata stores additional information related to a resource as string
+// values, for example internal identifiers. Helper methods allow to store and
+// read structured values
+type Metadata map[string]string
+
+// Set stores a value under the provided key. Strings are stored as is,
+// numbers and booleans in their textual representation, times as RFC 3339
+// and any other value encoded as JSON
+func (m *Metadata) Set(key string, value interface{}) error {
+	var v string
+	switch val := value.(type) {
+	case string:
+		v = val
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		v = fmt.Sprint(val)
+	case time.Time:
+		v = val.Format(time.RFC3339)
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		v = string(b)
+	}
+	if err := validateMetadataEntry(key, v); err != nil {
+		return err
+	}
+	if _, ok := (*m)[key]; !ok && len(*m) >= MaxMetadataKeys {
+		return fmt.Errorf("metadata exceeds %d keys", MaxMetadataKeys)
+	}
+	if *m == nil {
+		*m = make(Metadata)
+	}
+	(*m)[key] = v
+	return nil
+}
+
+// Int returns the value stored under the provided key as an integer
+func (m Metadata) Int(key string) (int64, error) {
+	v, ok := m[key]
+	if !ok {
+		return 0, errors.New("metadata key not found: " + key)
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// Bool returns the value stored under the provided key as a boolean
+func (m Metadata) Bool(key string) (bool, error) {
+	v, ok := m[key]
+	if !ok {
+		return false, errors.New("metadata key not found: " + key)
+	}
+	return strconv.ParseBool(v)
+}
+
+// Time returns the value stored under the provided key as a time, it must be
+// formatted as RFC 3339
+func (m Metadata) Time(key string) (time.Time, error) {
+	v, ok := m[key]
+	if !ok {
+		return time.Time{}, errors.New("metadata key not found: " + key)
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// Decode the JSON value stored under the provided key into 'v'
+func (m Metadata) Decode(key string, v interface{}) error {
+	val, ok := m[key]
+	if !ok {
+		return errors.New("metadata key not found: " + key)
+	}
+	return json.Unmarshal([]byte(val), v)
+}
+
+// Validate verifies the metadata is within the limits accepted by the service
+func (m Metadata) Validate() error {
+	if len(m) > MaxMetadataKeys {
+		return fmt.Errorf("metadata exceeds %d keys", MaxMetadataKeys)
+	}
+	for k, v := range m {
+		if err := validateMetadataEntry(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify a single metadata entry is within the limits accepted by the service
+func validateMetadataEntry(key, value string) error {
+	if key == "" {
+		return errors.New("metadata key can't be empty")
+	}
+	if utf8.RuneCountInString(key) > MaxMetadataKeyLength {
+		return fmt.Errorf("metadata key exceeds %d characters: %s", MaxMetadataKeyLength, key)
+	}
+	if utf8.RuneCountInString(value) > MaxMetadataValueLength {
+		return fmt.Errorf("metadata value exceeds %d characters: %s", MaxMetadataValueLength, key)
+	}
+	return nil
+}
+
+// Implemented by request payloads that can be verified before sending them
+// to the service
+type validator interface {
+	validate() error
+}
+
+func (o *Order) validate() error {
+	if err := o.Metadata.Validate(); err != nil {
+		return err
+	}
+	for _, li := range o.LineItems {
+		if err := li.Metadata.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, sl := range o.ShippingLines {
+		if err := sl.Metadata.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, tl := range o.TaxLines {
+		if err := tl.Metadata.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, c := range o.Charges {
+		if err := c.Metadata.Validate(); err != nil {
+			return err
+		}
+	}
+	// Existing fiscal entities may be referenced by ID alone
+	if fe := o.FiscalEntity; fe != nil && (fe.ID == "" || fe.TaxID != "") {
+		if err := validateFiscalEntity(fe); err != nil {
+			return err
+		}
+	}
+	if o.Checkout != nil {
+		return o.Checkout.validate()
+	}
+	return nil
+}
+
+func (c *Customer) validate() error           { return c.Metadata.Validate() }
+func (c *Charge) validate() error             { return c.Metadata.Validate() }
+func (r *Refund) validate() error             { return r.Metadata.Validate() }
+func (p *Plan) validate() error               { return p.Metadata.Validate() }
+func (p *PlanUpdate) validate() error         { return p.Metadata.Validate() }
+func (s *SubscriptionParams) validate() error { return s.Metadata.Validate() }
+func (l *LineItem) validate() error           { return l.Metadata.Validate() }
+func (l *ShippingLine) validate() error       { return l.Metadata.Validate() }
+func (l *TaxLine) validate() error            { return l.Metadata.Validate() }
+func (f *FiscalEntity) validate() error       { return f.Metadata.Validate() }
+
+func (c *Checkout) validate() error {
+	if err := c.Metadata.Validate(); err != nil {
+		return err
+	}
+	if c.OrderTemplate != nil {
+		return c.OrderTemplate.validate()
+	}
+	return nil
+}
+
+func (t *CheckoutOrderTemplate) validate() error {
+	if err := t.Metadata.Validate(); err != nil {
+		return err
+	}
+	for _, li := range t.LineItems {
+		if err := li.Metadata.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
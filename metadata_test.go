@@ -0,0 +1,101 @@
+package conekta
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetadata(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		var m Metadata
+		at := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+		type ref struct {
+			System string `json:"system"`
+			ID     int    `json:"id"`
+		}
+		for k, v := range map[string]interface{}{
+			"external_id": "usr_123",
+			"attempts":    3,
+			"vip":         true,
+			"since":       at,
+			"ref":         ref{System: "erp", ID: 7},
+		} {
+			if err := m.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if m["external_id"] != "usr_123" || m["ref"] != `{"system":"erp","id":7}` {
+			t.Errorf("invalid stored values: %v", m)
+		}
+		if n, err := m.Int("attempts"); err != nil || n != 3 {
+			t.Error("failed to read integer")
+		}
+		if b, err := m.Bool("vip"); err != nil || !b {
+			t.Error("failed to read boolean")
+		}
+		if ts, err := m.Time("since"); err != nil || !ts.Equal(at) {
+			t.Error("failed to read time")
+		}
+		r := ref{}
+		if err := m.Decode("ref", &r); err != nil || r.System != "erp" || r.ID != 7 {
+			t.Error("failed to decode value")
+		}
+		if _, err := m.Int("missing"); err == nil {
+			t.Error("failed to detect missing key")
+		}
+		if _, err := m.Bool("external_id"); err == nil {
+			t.Error("failed to detect invalid value")
+		}
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		m := Metadata{}
+		if m.Set("", "value") == nil {
+			t.Error("empty key accepted")
+		}
+		if m.Set(strings.Repeat("k", MaxMetadataKeyLength+1), "value") == nil {
+			t.Error("long key accepted")
+		}
+		if m.Set("key", strings.Repeat("v", MaxMetadataValueLength+1)) == nil {
+			t.Error("long value accepted")
+		}
+		if m.Set("key", strings.Repeat("ñ", MaxMetadataValueLength)) != nil {
+			t.Error("value length should be measured in characters")
+		}
+		for i := len(m); i < MaxMetadataKeys; i++ {
+			if err := m.Set("key_"+strconv.Itoa(i), i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if m.Set("extra", "value") == nil {
+			t.Error("failed to enforce keys limit")
+		}
+		if m.Set("key", "updated") != nil {
+			t.Error("existing keys should be updatable at the limit")
+		}
+		m["extra"] = "value"
+		if m.Validate() == nil {
+			t.Error("failed to detect too many keys")
+		}
+	})
+
+	t.Run("Payloads", func(t *testing.T) {
+		long := Metadata{"key": strings.Repeat("v", MaxMetadataValueLength+1)}
+		payloads := []validator{
+			&Order{Charges: []Charge{{Metadata: long}}},
+			&Customer{Metadata: long},
+			&Checkout{OrderTemplate: &CheckoutOrderTemplate{LineItems: []LineItem{{Metadata: long}}}},
+			&CheckoutOrderTemplate{Metadata: long},
+		}
+		for i, p := range payloads {
+			if p.validate() == nil {
+				t.Errorf("payload %d: invalid metadata accepted", i)
+			}
+		}
+		if (&Order{Metadata: Metadata{"key": "value"}}).validate() != nil {
+			t.Error("valid metadata rejected")
+		}
+	})
+}
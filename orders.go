@@ -8,7 +8,9 @@ import (
 
 // Defines the public interface required to access available 'orders' methods
 type OrdersAPI interface {
-	// Creates a new order
+	// Creates a new order. If a card charge requires 3-D Secure authentication
+	// the order is created and 'order.AuthenticationRequired()' returns the
+	// pending challenge
 	// https://developers.conekta.com/api?language=bash#create-order
 	Create(order *Order) error
 
@@ -20,10 +22,59 @@ type OrdersAPI interface {
 	// https://developers.conekta.com/api?language=bash#capture-order
 	Capture(orderID string) error
 
+	// Process a pre-authorized order capturing the provided amount, in cents. If
+	// the amount is 0 the full order amount is captured. The updated order is
+	// returned, with a 'paid' payment status
+	// https://developers.conekta.com/api?language=bash#capture-order
+	CaptureAmount(orderID string, amount uint32) (*Order, error)
+
+	// Releases the funds of a pre-authorized order without charging them. The
+	// updated order is returned, with a 'voided' payment status
+	// https://developers.conekta.com/api?language=bash#void-order
+	Void(orderID string) (*Order, error)
+
+	// Retrieves an existing order
+	// https://developers.conekta.com/api?language=bash#order
+	Get(orderID string) (*Order, error)
+
+	// Retrieves an order after the buyer returns from a 3-D Secure challenge.
+	// If the authentication is still pending an '*AuthenticationRequired'
+	// error is returned along with the order, otherwise the order payment
+	// status reflects the final result of the payment
+	// https://developers.conekta.com/api?language=bash#order
+	ResumeAuthentication(orderID string) (*Order, error)
+
 	// A Refund details the amount and reason why an order was refunded
 	// https://developers.conekta.com/api?language=bash#refund-order
 	Refund(orderID string, r *Refund) error
 
+	// Refunds an order after verifying locally the amount doesn't exceed what
+	// remains refundable. Set 'ChargeID' on the refund to refund a specific
+	// charge of a multi-charge order. The order is updated with the data
+	// registered on the service
+	// https://developers.conekta.com/api?language=bash#refund-order
+	RefundOrder(order *Order, r *Refund) error
+
+	// Lists the refunds applied to all the charges of an order
+	// https://developers.conekta.com/api?language=bash#refund-order
+	ListRefunds(orderID string) ([]Refund, error)
+
+	// Adds a new charge to an existing order, for example to retry a declined
+	// order with a different card. The charge is updated with the data
+	// registered on the service. If the charge requires 3-D Secure
+	// authentication an '*AuthenticationRequired' error is returned
+	// https://developers.conekta.com/api?language=bash#create-charge
+	CreateCharge(orderID string, charge *Charge) error
+
+	// Retrieves a charge of an existing order
+	// https://developers.conekta.com/api?language=bash#charge
+	GetCharge(orderID, chargeID string) (*Charge, error)
+
+	// Lists the charges of an existing order. If no options are provided all
+	// charges are returned
+	// https://developers.conekta.com/api?language=bash#charge
+	ListCharges(orderID string, opts *ListOptions) ([]Charge, error)
+
 	// Create a new line item
 	// https://developers.conekta.com/api?language=bash#create-line-item
 	CreateLineItem(orderID string, item *LineItem) (string, error)
@@ -79,6 +130,7 @@ type ordersClient struct {
 
 func (oc *ordersClient) Create(order *Order) error {
 	b, err := oc.c.request(&requestOptions{
+		op:       "orders.create",
 		endpoint: baseUrl + "orders",
 		method:   http.MethodPost,
 		data:     order,
@@ -92,6 +144,7 @@ func (oc *ordersClient) Create(order *Order) error {
 
 func (oc *ordersClient) Update(order *Order) error {
 	b, err := oc.c.request(&requestOptions{
+		op:       "orders.update",
 		endpoint: baseUrl + path.Join("orders", order.ID),
 		method:   http.MethodPut,
 		data:     order,
@@ -104,10 +157,43 @@ func (oc *ordersClient) Update(order *Order) error {
 }
 
 func (oc *ordersClient) Capture(orderID string) error {
+	_, err := oc.CaptureAmount(orderID, 0)
+	return err
+}
+
+func (oc *ordersClient) CaptureAmount(orderID string, amount uint32) (*Order, error) {
+	data := map[string]uint32{}
+	if amount > 0 {
+		data["amount"] = amount
+	}
+	return send[Order](oc.c, "orders.capture", http.MethodPost, baseUrl+path.Join("orders", orderID, "capture"), data)
+}
+
+func (oc *ordersClient) Void(orderID string) (*Order, error) {
+	return send[Order](oc.c, "orders.void", http.MethodPost, baseUrl+path.Join("orders", orderID, "void"), nil)
+}
+
+func (oc *ordersClient) Get(orderID string) (*Order, error) {
+	return send[Order](oc.c, "orders.get", http.MethodGet, baseUrl+path.Join("orders", orderID), nil)
+}
+
+func (oc *ordersClient) ResumeAuthentication(orderID string) (*Order, error) {
+	order, err := oc.Get(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if auth := order.AuthenticationRequired(); auth != nil {
+		return order, auth
+	}
+	return order, nil
+}
+
+func (oc *ordersClient) Refund(orderID string, r *Refund) error {
 	_, err := oc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("orders", orderID, "capture"),
+		op:       "orders.refund",
+		endpoint: baseUrl + path.Join("orders", orderID, "refunds"),
 		method:   http.MethodPost,
-		data:     map[string]string{orderID: orderID},
+		data:     r,
 	})
 	if err != nil {
 		return err
@@ -115,20 +201,74 @@ func (oc *ordersClient) Capture(orderID string) error {
 	return nil
 }
 
-func (oc *ordersClient) Refund(orderID string, r *Refund) error {
-	_, err := oc.c.request(&requestOptions{
-		endpoint: baseUrl + path.Join("orders", orderID, "refunds"),
+func (oc *ordersClient) RefundOrder(order *Order, r *Refund) error {
+	if err := order.ValidateRefund(r); err != nil {
+		return err
+	}
+	b, err := oc.c.request(&requestOptions{
+		op:       "orders.refund",
+		endpoint: baseUrl + path.Join("orders", order.ID, "refunds"),
 		method:   http.MethodPost,
 		data:     r,
 	})
 	if err != nil {
 		return err
 	}
+	json.Unmarshal(b, order)
+	return nil
+}
+
+func (oc *ordersClient) ListRefunds(orderID string) ([]Refund, error) {
+	charges, err := oc.ListCharges(orderID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []Refund
+	for _, c := range charges {
+		list = append(list, c.Refunds...)
+	}
+	return list, nil
+}
+
+func (oc *ordersClient) CreateCharge(orderID string, charge *Charge) error {
+	b, err := oc.c.request(&requestOptions{
+		op:       "orders.create_charge",
+		endpoint: baseUrl + path.Join("orders", orderID, "charges"),
+		method:   http.MethodPost,
+		data:     charge,
+	})
+	if err != nil {
+		return err
+	}
+	json.Unmarshal(b, charge)
+	if auth := authenticationRequired(orderID, charge.NextAction); auth != nil {
+		return auth
+	}
 	return nil
 }
 
+func (oc *ordersClient) GetCharge(orderID, chargeID string) (*Charge, error) {
+	b, err := oc.c.request(&requestOptions{
+		op:       "orders.get_charge",
+		endpoint: baseUrl + path.Join("orders", orderID, "charges", chargeID),
+		method:   http.MethodGet,
+	})
+	if err != nil {
+		return nil, err
+	}
+	charge := &Charge{}
+	json.Unmarshal(b, charge)
+	return charge, nil
+}
+
+func (oc *ordersClient) ListCharges(orderID string, opts *ListOptions) ([]Charge, error) {
+	endpoint := baseUrl + path.Join("orders", orderID, "charges")
+	return listAll[Charge](oc.c, "orders.list_charges", endpoint, opts)
+}
+
 func (oc *ordersClient) CreateLineItem(orderID string, item *LineItem) (string, error) {
 	res, err := oc.c.request(&requestOptions{
+		op:       "orders.create_line_item",
 		endpoint: baseUrl + path.Join("orders", orderID, "line_items"),
 		method:   http.MethodPost,
 		data:     item,
@@ -144,6 +284,7 @@ func (oc *ordersClient) CreateLineItem(orderID string, item *LineItem) (string,
 
 func (oc *ordersClient) UpdateLineItem(orderID string, item *LineItem) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.update_line_item",
 		endpoint: baseUrl + path.Join("orders", orderID, "line_items", item.ID),
 		method:   http.MethodPut,
 		data:     item,
@@ -156,6 +297,7 @@ func (oc *ordersClient) UpdateLineItem(orderID string, item *LineItem) error {
 
 func (oc *ordersClient) DeleteLineItem(orderID, itemID string) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.delete_line_item",
 		endpoint: baseUrl + path.Join("orders", orderID, "line_items", itemID),
 		method:   http.MethodDelete,
 		data:     itemID,
@@ -165,6 +307,7 @@ func (oc *ordersClient) DeleteLineItem(orderID, itemID string) error {
 
 func (oc *ordersClient) CreateDiscountLine(orderID string, discount *DiscountLine) (string, error) {
 	res, err := oc.c.request(&requestOptions{
+		op:       "orders.create_discount_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "discount_lines"),
 		method:   http.MethodPost,
 		data:     discount,
@@ -180,6 +323,7 @@ func (oc *ordersClient) CreateDiscountLine(orderID string, discount *DiscountLin
 
 func (oc *ordersClient) UpdateDiscountLine(orderID string, discount *DiscountLine) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.update_discount_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "discount_lines", discount.ID),
 		method:   http.MethodPut,
 		data:     discount,
@@ -192,6 +336,7 @@ func (oc *ordersClient) UpdateDiscountLine(orderID string, discount *DiscountLin
 
 func (oc *ordersClient) DeleteDiscountLine(orderID, discountID string) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.delete_discount_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "discount_lines", discountID),
 		method:   http.MethodDelete,
 		data:     discountID,
@@ -201,6 +346,7 @@ func (oc *ordersClient) DeleteDiscountLine(orderID, discountID string) error {
 
 func (oc *ordersClient) CreateTaxLine(orderID string, tax *TaxLine) (string, error) {
 	res, err := oc.c.request(&requestOptions{
+		op:       "orders.create_tax_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "tax_lines"),
 		method:   http.MethodPost,
 		data:     tax,
@@ -216,6 +362,7 @@ func (oc *ordersClient) CreateTaxLine(orderID string, tax *TaxLine) (string, err
 
 func (oc *ordersClient) UpdateTaxLine(orderID string, tax *TaxLine) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.update_tax_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "tax_lines", tax.ID),
 		method:   http.MethodPut,
 		data:     tax,
@@ -228,6 +375,7 @@ func (oc *ordersClient) UpdateTaxLine(orderID string, tax *TaxLine) error {
 
 func (oc *ordersClient) DeleteTaxLine(orderID, taxID string) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.delete_tax_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "tax_lines", taxID),
 		method:   http.MethodDelete,
 		data:     taxID,
@@ -237,6 +385,7 @@ func (oc *ordersClient) DeleteTaxLine(orderID, taxID string) error {
 
 func (oc *ordersClient) CreateShippingLine(orderID string, line *ShippingLine) (string, error) {
 	res, err := oc.c.request(&requestOptions{
+		op:       "orders.create_shipping_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "shipping_lines"),
 		method:   http.MethodPost,
 		data:     line,
@@ -252,6 +401,7 @@ func (oc *ordersClient) CreateShippingLine(orderID string, line *ShippingLine) (
 
 func (oc *ordersClient) UpdateShippingLine(orderID string, line *ShippingLine) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.update_shipping_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "shipping_lines", line.ID),
 		method:   http.MethodPut,
 		data:     line,
@@ -264,6 +414,7 @@ func (oc *ordersClient) UpdateShippingLine(orderID string, line *ShippingLine) e
 
 func (oc *ordersClient) DeleteShippingLine(orderID, lineID string) error {
 	_, err := oc.c.request(&requestOptions{
+		op:       "orders.delete_shipping_line",
 		endpoint: baseUrl + path.Join("orders", orderID, "shipping_lines", lineID),
 		method:   http.MethodDelete,
 		data:     lineID,
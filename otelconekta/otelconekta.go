@@ -0,0 +1,126 @@
+// Package otelconekta provides OpenTelemetry instrumentation for the Conekta
+// API client. A span is created for every API operation, named after it, for
+// example 'conekta.orders.create', and latency and error metrics are recorded
+// per operation.
+//
+//	hooks, err := otelconekta.NewHooks(nil)
+//	client, err := conekta.NewClient("API_KEY", &conekta.Options{Hooks: hooks})
+//
+// Use 'Client.WithContext' to attach the spans to the caller's trace.
+//
+// The package is a separate module so the OpenTelemetry dependencies are only
+// required by applications using it:
+//
+//	go get github.com/fairbank-io/conekta/otelconekta
+package otelconekta
+
+import (
+	"errors"
+
+	"github.com/fairbank-io/conekta"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name reported for the instrumentation scope
+const instrumentationName = "github.com/fairbank-io/conekta/otelconekta"
+
+// Attribute keys reported on spans and metrics
+const (
+	operationKey  = attribute.Key("conekta.operation")
+	apiVersionKey = attribute.Key("conekta.api_version")
+	resourceKey   = attribute.Key("conekta.resource_id")
+	logIDKey      = attribute.Key("conekta.log_id")
+	methodKey     = attribute.Key("http.request.method")
+	statusKey     = attribute.Key("http.response.status_code")
+	errorTypeKey  = attribute.Key("error.type")
+)
+
+// Available configuration options, if not provided the global providers will
+// be used
+type Options struct {
+	// Provider used to create spans
+	TracerProvider trace.TracerProvider
+
+	// Provider used to record metrics
+	MeterProvider metric.MeterProvider
+}
+
+// NewHooks returns client hooks that report every API operation using the
+// configured OpenTelemetry providers
+func NewHooks(options *Options) (*conekta.Hooks, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	tp := options.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := options.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	tracer := tp.Tracer(instrumentationName)
+	meter := mp.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram("conekta.client.operation.duration",
+		metric.WithDescription("Duration of Conekta API operations"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64Counter("conekta.client.operation.errors",
+		metric.WithDescription("Number of failed Conekta API operations"),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &conekta.Hooks{
+		BeforeRequest: func(req *conekta.RequestInfo) {
+			req.Context, _ = tracer.Start(req.Context, "conekta."+req.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					operationKey.String(req.Operation),
+					apiVersionKey.String(req.APIVersion),
+					methodKey.String(req.Method),
+				))
+		},
+		AfterRequest: func(res *conekta.ResponseInfo) {
+			span := trace.SpanFromContext(res.Context)
+			defer span.End()
+
+			attrs := []attribute.KeyValue{operationKey.String(res.Operation)}
+			if res.Status != 0 {
+				span.SetAttributes(statusKey.Int(res.Status))
+			}
+			if res.ResourceID != "" {
+				span.SetAttributes(resourceKey.String(res.ResourceID))
+			}
+			if res.LogID != "" {
+				span.SetAttributes(logIDKey.String(res.LogID))
+			}
+			if res.Err != nil {
+				errType := errorType(res.Err)
+				attrs = append(attrs, errorTypeKey.String(errType))
+				span.SetAttributes(errorTypeKey.String(errType))
+				span.RecordError(res.Err)
+				span.SetStatus(codes.Error, res.Err.Error())
+				failures.Add(res.Context, 1, metric.WithAttributes(attrs...))
+			}
+			duration.Record(res.Context, res.Latency.Seconds(), metric.WithAttributes(attrs...))
+		},
+	}, nil
+}
+
+// Classify an error returned by the client
+func errorType(err error) string {
+	var apiErr *conekta.APIError
+	if errors.As(err, &apiErr) && apiErr.Type != "" {
+		return apiErr.Type
+	}
+	return "network_error"
+}
@@ -0,0 +1,102 @@
+package otelconekta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fairbank-io/conekta"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestHooks(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	hooks, err := NewHooks(&Options{TracerProvider: tp, MeterProvider: mp})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(op string, status int, err error) {
+		req := &conekta.RequestInfo{Context: context.Background(), Operation: op, Method: "POST", APIVersion: "v2.0.0"}
+		hooks.BeforeRequest(req)
+		res := &conekta.ResponseInfo{
+			RequestInfo: *req,
+			Status:      status,
+			Latency:     10 * time.Millisecond,
+			ResourceID:  "ord_1",
+			Err:         err,
+		}
+		if e, ok := err.(*conekta.APIError); ok {
+			res.LogID = e.LogID
+		}
+		hooks.AfterRequest(res)
+	}
+	run("orders.create", 200, nil)
+	run("orders.get", 402, &conekta.APIError{Type: "processing_error", LogID: "log_1"})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected number of spans: %d", len(spans))
+	}
+	attrs := func(s tracetest.SpanStub) map[string]string {
+		m := map[string]string{}
+		for _, kv := range s.Attributes {
+			m[string(kv.Key)] = kv.Value.Emit()
+		}
+		return m
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		s := spans[0]
+		if s.Name != "conekta.orders.create" {
+			t.Errorf("invalid span name: %s", s.Name)
+		}
+		a := attrs(s)
+		if a["http.response.status_code"] != "200" || a["conekta.resource_id"] != "ord_1" || a["conekta.api_version"] != "v2.0.0" {
+			t.Errorf("invalid span attributes: %v", a)
+		}
+		if s.Status.Code == codes.Error {
+			t.Error("successful operation reported as error")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := spans[1]
+		if s.Name != "conekta.orders.get" {
+			t.Errorf("invalid span name: %s", s.Name)
+		}
+		a := attrs(s)
+		if a["error.type"] != "processing_error" || a["http.response.status_code"] != "402" || a["conekta.log_id"] != "log_1" {
+			t.Errorf("invalid span attributes: %v", a)
+		}
+		if s.Status.Code != codes.Error {
+			t.Error("failed operation should have an error status")
+		}
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		rm := metricdata.ResourceMetrics{}
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatal(err)
+		}
+		found := map[string]bool{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				found[m.Name] = true
+				if sum, ok := m.Data.(metricdata.Sum[int64]); ok && len(sum.DataPoints) == 1 && sum.DataPoints[0].Value != 1 {
+					t.Errorf("invalid error count: %d", sum.DataPoints[0].Value)
+				}
+			}
+		}
+		if !found["conekta.client.operation.duration"] || !found["conekta.client.operation.errors"] {
+			t.Errorf("missing metrics: %v", found)
+		}
+	})
+}
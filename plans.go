@@ -16,9 +16,22 @@ type PlansAPI interface {
 	// https://developers.conekta.com/api?language=bash#update-plan
 	Update(update *PlanUpdate) (*Plan, error)
 
+	// Replaces all the mutable fields of an existing plan with the values
+	// provided, including zero values. A zero frequency is sent as 1
+	// https://developers.conekta.com/api?language=bash#update-plan
+	Replace(plan *Plan) (*Plan, error)
+
 	// Deletes plan data
 	// https://developers.conekta.com/api?language=bash#delete-plan
 	Delete(planID string) error
+
+	// Retrieves an existing plan
+	// https://developers.conekta.com/api?language=bash#plan
+	Get(planID string) (*Plan, error)
+
+	// Lists existing plans. If no options are provided all plans are returned
+	// https://developers.conekta.com/api?language=bash#plan
+	List(opts *ListOptions) ([]Plan, error)
 }
 
 type plansClient struct {
@@ -27,6 +40,7 @@ type plansClient struct {
 
 func (pc *plansClient) Create(plan *Plan) error {
 	b, err := pc.c.request(&requestOptions{
+		op:       "plans.create",
 		endpoint: baseUrl + "plans",
 		method:   http.MethodPost,
 		data:     plan,
@@ -40,6 +54,7 @@ func (pc *plansClient) Create(plan *Plan) error {
 
 func (pc *plansClient) Update(update *PlanUpdate) (*Plan, error) {
 	b, err := pc.c.request(&requestOptions{
+		op:       "plans.update",
 		endpoint: baseUrl + path.Join("plans", update.ID),
 		method:   http.MethodPut,
 		data:     update,
@@ -52,11 +67,68 @@ func (pc *plansClient) Update(update *PlanUpdate) (*Plan, error) {
 	return plan, err
 }
 
+// Update payload including all the mutable plan fields, even zero values
+type planReplace struct {
+	Name            string   `json:"name"`
+	Amount          uint32   `json:"amount"`
+	Currency        string   `json:"currency"`
+	Interval        string   `json:"interval"`
+	Frequency       uint32   `json:"frequency"`
+	TrialPeriodDays uint32   `json:"trial_period_days"`
+	ExpiryCount     uint32   `json:"expiry_count"`
+	Metadata        Metadata `json:"metadata,omitempty"`
+}
+
+func (p *planReplace) validate() error { return p.Metadata.Validate() }
+
+func (pc *plansClient) Replace(plan *Plan) (*Plan, error) {
+	b, err := pc.c.request(&requestOptions{
+		op:       "plans.update",
+		endpoint: baseUrl + path.Join("plans", plan.ID),
+		method:   http.MethodPut,
+		data: &planReplace{
+			Name:            plan.Name,
+			Amount:          plan.Amount,
+			Currency:        plan.Currency,
+			Interval:        plan.Interval,
+			Frequency:       frequency(plan),
+			TrialPeriodDays: plan.TrialPeriodDays,
+			ExpiryCount:     plan.ExpiryCount,
+			Metadata:        plan.Metadata,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &Plan{}
+	json.Unmarshal(b, res)
+	return res, nil
+}
+
 func (pc *plansClient) Delete(planID string) error {
 	_, err := pc.c.request(&requestOptions{
+		op:       "plans.delete",
 		endpoint: baseUrl + path.Join("plans", planID),
 		method:   http.MethodDelete,
 		data:     planID,
 	})
 	return err
 }
+
+func (pc *plansClient) Get(planID string) (*Plan, error) {
+	b, err := pc.c.request(&requestOptions{
+		op:       "plans.get",
+		endpoint: baseUrl + path.Join("plans", planID),
+		method:   http.MethodGet,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	json.Unmarshal(b, plan)
+	return plan, nil
+}
+
+func (pc *plansClient) List(opts *ListOptions) ([]Plan, error) {
+	return listAll[Plan](pc.c, "plans.list", baseUrl+"plans", opts)
+}
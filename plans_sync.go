@@ -0,0 +1,126 @@
+package conekta
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Actions performed when synchronizing plans
+const (
+	PlanSyncCreate = "create"
+	PlanSyncUpdate = "update"
+	PlanSyncDelete = "delete"
+)
+
+// A change required to match the desired plans catalog
+type PlanSyncAction struct {
+	// Type of change: create, update or delete
+	Action string
+
+	// Desired plan state, or the plan to delete
+	Plan Plan
+
+	// Plan as currently registered on the service, if any
+	Current *Plan
+}
+
+// Plans synchronization settings
+type PlanSyncOptions struct {
+	// Only report the changes required, without applying them
+	DryRun bool
+
+	// Delete registered plans not included in the desired catalog
+	Prune bool
+}
+
+// SyncPlans creates, updates and deletes plans as required to match the desired
+// catalog. Plans are matched using their ID, so all desired plans must include
+// one. The list of changes required, or applied, is returned; on error the
+// changes applied before the failure are returned
+func SyncPlans(api PlansAPI, desired []Plan, options *PlanSyncOptions) ([]PlanSyncAction, error) {
+	if options == nil {
+		options = &PlanSyncOptions{}
+	}
+	current, err := api.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[string]*Plan, len(current))
+	for i := range current {
+		registered[current[i].ID] = &current[i]
+	}
+
+	// Calculate required changes
+	var actions []PlanSyncAction
+	wanted := make(map[string]bool, len(desired))
+	for _, plan := range desired {
+		if plan.ID == "" {
+			return nil, errors.New("desired plans require an ID")
+		}
+		if wanted[plan.ID] {
+			return nil, errors.New("duplicated plan ID: " + plan.ID)
+		}
+		wanted[plan.ID] = true
+		cur, ok := registered[plan.ID]
+		switch {
+		case !ok:
+			actions = append(actions, PlanSyncAction{Action: PlanSyncCreate, Plan: plan})
+		case !samePlan(cur, &plan):
+			actions = append(actions, PlanSyncAction{Action: PlanSyncUpdate, Plan: plan, Current: cur})
+		}
+	}
+	if options.Prune {
+		var extra []string
+		for id := range registered {
+			if !wanted[id] {
+				extra = append(extra, id)
+			}
+		}
+		sort.Strings(extra)
+		for _, id := range extra {
+			actions = append(actions, PlanSyncAction{Action: PlanSyncDelete, Plan: *registered[id], Current: registered[id]})
+		}
+	}
+	if options.DryRun {
+		return actions, nil
+	}
+
+	// Apply changes
+	for i, a := range actions {
+		plan := a.Plan
+		switch a.Action {
+		case PlanSyncCreate:
+			err = api.Create(&plan)
+		case PlanSyncUpdate:
+			// Send all the mutable fields so zero values are applied as well
+			_, err = api.Replace(&plan)
+		case PlanSyncDelete:
+			err = api.Delete(plan.ID)
+		}
+		if err != nil {
+			return actions[:i], err
+		}
+	}
+	return actions, nil
+}
+
+// Compare the mutable fields of a registered plan 'a' with a desired plan 'b'.
+// Metadata is only compared when provided on the desired plan
+func samePlan(a, b *Plan) bool {
+	if len(b.Metadata) > 0 && len(a.Metadata) != len(b.Metadata) {
+		return false
+	}
+	for k, v := range b.Metadata {
+		if a.Metadata[k] != v {
+			return false
+		}
+	}
+	return a.Name == b.Name &&
+		a.Amount == b.Amount &&
+		strings.EqualFold(a.Currency, b.Currency) &&
+		a.Interval == b.Interval &&
+		frequency(a) == frequency(b) &&
+		a.TrialPeriodDays == b.TrialPeriodDays &&
+		a.ExpiryCount == b.ExpiryCount
+}
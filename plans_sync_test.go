@@ -0,0 +1,117 @@
+package conekta
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// In-memory plans catalog
+type memoryPlans struct {
+	plans map[string]Plan
+}
+
+func (m *memoryPlans) Create(plan *Plan) error {
+	m.plans[plan.ID] = *plan
+	return nil
+}
+
+func (m *memoryPlans) Update(update *PlanUpdate) (*Plan, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *memoryPlans) Replace(plan *Plan) (*Plan, error) {
+	if _, ok := m.plans[plan.ID]; !ok {
+		return nil, errors.New("plan not found")
+	}
+	m.plans[plan.ID] = *plan
+	return plan, nil
+}
+
+func (m *memoryPlans) Delete(planID string) error {
+	delete(m.plans, planID)
+	return nil
+}
+
+func (m *memoryPlans) Get(planID string) (*Plan, error) {
+	plan, ok := m.plans[planID]
+	if !ok {
+		return nil, errors.New("plan not found")
+	}
+	return &plan, nil
+}
+
+func (m *memoryPlans) List(opts *ListOptions) ([]Plan, error) {
+	var list []Plan
+	for _, p := range m.plans {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func TestSyncPlans(t *testing.T) {
+	api := &memoryPlans{plans: map[string]Plan{
+		"basic": {ID: "basic", Name: "Basic", Amount: 1000, Currency: "MXN", Interval: IntervalMonth, Frequency: 1, TrialPeriodDays: 15},
+		"old":   {ID: "old", Name: "Old", Amount: 500, Currency: "MXN", Interval: IntervalMonth, Frequency: 1},
+	}}
+	desired := []Plan{
+		{ID: "basic", Name: "Basic", Amount: 1000, Currency: "MXN", Interval: IntervalMonth, Frequency: 1},
+		{ID: "pro", Name: "Pro", Amount: 3000, Currency: "MXN", Interval: IntervalMonth, Frequency: 1},
+	}
+
+	actions, err := SyncPlans(api, desired, &PlanSyncOptions{DryRun: true, Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 3 || len(api.plans) != 2 || api.plans["basic"].TrialPeriodDays != 15 {
+		t.Error("dry run should only report changes")
+	}
+
+	actions, err = SyncPlans(api, desired, &PlanSyncOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 3 {
+		t.Errorf("unexpected changes: %+v", actions)
+	}
+	if api.plans["basic"].TrialPeriodDays != 0 {
+		t.Error("failed to reset trial period")
+	}
+	if _, ok := api.plans["old"]; ok {
+		t.Error("failed to prune plan")
+	}
+
+	actions, err = SyncPlans(api, desired, &PlanSyncOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("catalog should be in sync, got: %+v", actions)
+	}
+}
+
+func TestPlanFrequency(t *testing.T) {
+	// The service reports a frequency of 1 for plans created without one
+	api := &memoryPlans{plans: map[string]Plan{
+		"basic": {ID: "basic", Name: "Basic", Amount: 1000, Currency: "MXN", Interval: IntervalMonth, Frequency: 1},
+	}}
+	desired := []Plan{{ID: "basic", Name: "Basic", Amount: 1000, Currency: "MXN", Interval: IntervalMonth}}
+	actions, err := SyncPlans(api, desired, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("default frequency should match, got: %+v", actions)
+	}
+
+	rt := &recordingTransport{reply: `{"id": "basic", "frequency": 1}`}
+	client := recordingClient(t, rt)
+	if _, err := client.Plans.Replace(&desired[0]); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rt.bodies[0], `"frequency":1`) {
+		t.Errorf("default frequency should be sent: %s", rt.bodies[0])
+	}
+}
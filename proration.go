@@ -0,0 +1,118 @@
+package conekta
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Proration describes the amounts involved in changing the plan of a
+// subscription in the middle of a billing cycle
+type Proration struct {
+	// Unused portion of the current plan, in cents, credited to the customer
+	Credit uint32
+
+	// Cost of the new plan for the remainder of the cycle, in cents
+	Debit uint32
+
+	// Fraction of the billing cycle remaining at the time of the change
+	Remaining float64
+
+	// Whether the billing cycle restarts at the time of the change, which
+	// happens when the new plan uses a different billing interval
+	ResetsCycle bool
+
+	// Currency of the amounts, ISO 4217
+	Currency string
+
+	// Plan the subscription is switching to
+	Plan *Plan
+}
+
+// Net returns the amount owed by the customer in cents, negative if the
+// customer is owed money instead
+func (p *Proration) Net() int64 {
+	return int64(p.Debit) - int64(p.Credit)
+}
+
+// Order returns a one-off order charging the net amount owed to the customer's
+// default payment source, 'nil' if nothing is owed
+func (p *Proration) Order(customerID string) *Order {
+	net := p.Net()
+	if net <= 0 {
+		return nil
+	}
+	name := "plan change"
+	if p.Plan != nil && p.Plan.Name != "" {
+		name = "plan change: " + p.Plan.Name
+	}
+	return &Order{
+		Currency: strings.ToUpper(p.Currency),
+		CustomerInfo: CustomerInfo{
+			CustomerID: customerID,
+		},
+		LineItems: []LineItem{
+			{
+				Name:      "Proration for " + name,
+				UnitPrice: uint32(net),
+				Quantity:  1,
+			},
+		},
+		Charges: []Charge{
+			{
+				PaymentMethod: Card{Type: "default"},
+			},
+		},
+	}
+}
+
+// ProratePlanChange calculates the credit and debit amounts for switching a
+// subscription from 'current' to 'next' plan at the provided time, using the
+// subscription's billing cycle window. When both plans share the same billing
+// interval the new plan is charged for the remainder of the cycle, otherwise
+// the cycle restarts and the full amount of the new plan is charged
+func ProratePlanChange(current, next *Plan, sub *Subscription, at time.Time) (*Proration, error) {
+	if current == nil || next == nil || sub == nil {
+		return nil, errors.New("plans and subscription are required")
+	}
+	if !strings.EqualFold(current.Currency, next.Currency) {
+		return nil, errors.New("plans must use the same currency")
+	}
+	if sub.BillingCycleEnd <= sub.BillingCycleStart {
+		return nil, errors.New("invalid subscription billing cycle")
+	}
+
+	// Fraction of the cycle not yet consumed
+	start := unixTime(sub.BillingCycleStart)
+	end := unixTime(sub.BillingCycleEnd)
+	remaining := float64(end.Sub(at)) / float64(end.Sub(start))
+	remaining = math.Max(0, math.Min(1, remaining))
+
+	p := &Proration{
+		Credit:    prorate(current.Amount, remaining),
+		Remaining: remaining,
+		Currency:  current.Currency,
+		Plan:      next,
+	}
+	if current.Interval == next.Interval && frequency(current) == frequency(next) {
+		p.Debit = prorate(next.Amount, remaining)
+	} else {
+		p.Debit = next.Amount
+		p.ResetsCycle = true
+	}
+	return p, nil
+}
+
+// Return the rounded portion of an amount
+func prorate(amount uint32, fraction float64) uint32 {
+	return uint32(math.Round(float64(amount) * fraction))
+}
+
+// Return the plan billing frequency, defaults to 1
+func frequency(plan *Plan) uint32 {
+	if plan.Frequency == 0 {
+		return 1
+	}
+	return plan.Frequency
+}
@@ -0,0 +1,51 @@
+package conekta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProratePlanChange(t *testing.T) {
+	start := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	sub := &Subscription{
+		BillingCycleStart: uint32(start.Unix()),
+		BillingCycleEnd:   uint32(start.AddDate(0, 0, 30).Unix()),
+	}
+	basic := &Plan{Amount: 3000, Currency: "MXN", Interval: IntervalMonth}
+	premium := &Plan{Name: "premium", Amount: 9000, Currency: "mxn", Interval: IntervalMonth, Frequency: 1}
+
+	t.Run("Upgrade", func(t *testing.T) {
+		p, err := ProratePlanChange(basic, premium, sub, start.AddDate(0, 0, 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Credit != 2000 || p.Debit != 6000 || p.Net() != 4000 || p.ResetsCycle {
+			t.Errorf("invalid proration: %+v", p)
+		}
+		order := p.Order("cus_123")
+		if order == nil || order.LineItems[0].UnitPrice != 4000 || order.Currency != "MXN" {
+			t.Error("invalid proration order")
+		}
+	})
+
+	t.Run("Downgrade", func(t *testing.T) {
+		p, _ := ProratePlanChange(premium, basic, sub, start.AddDate(0, 0, 15))
+		if p.Net() != -3000 || p.Order("cus_123") != nil {
+			t.Errorf("invalid proration: %+v", p)
+		}
+	})
+
+	t.Run("IntervalChange", func(t *testing.T) {
+		yearly := &Plan{Amount: 30000, Currency: "MXN", Interval: IntervalYear}
+		p, _ := ProratePlanChange(basic, yearly, sub, start.AddDate(0, 0, 30))
+		if p.Credit != 0 || p.Debit != 30000 || !p.ResetsCycle {
+			t.Errorf("invalid proration: %+v", p)
+		}
+	})
+
+	t.Run("Currency", func(t *testing.T) {
+		if _, err := ProratePlanChange(basic, &Plan{Currency: "USD"}, sub, start); err == nil {
+			t.Error("failed to detect currency mismatch")
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package conekta
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lower bound for the adaptive slowdown applied when the service reports
+// rate limit errors, as a fraction of the configured rate
+const minRateFactor = 1.0 / 16
+
+// Amount restored to the rate factor after each successful request
+const rateRecoveryStep = 0.05
+
+// Client side rate limit configuration. Requests exceeding the limit will
+// wait until allowed, or until the client context is done
+type RateLimit struct {
+	// Sustained number of requests allowed per second
+	Rate float64
+
+	// Maximum number of requests allowed in a single burst, at least 1
+	Burst uint
+}
+
+// Token bucket limiter, safe for concurrent use. When the service reports
+// rate limit errors the effective rate is halved, and gradually restored
+// with every successful request
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	factor float64
+	tokens float64
+	last   time.Time
+}
+
+// Return a new limiter for the provided configuration, 'nil' if no
+// limit is required
+func newLimiter(rl *RateLimit) *limiter {
+	if rl == nil || rl.Rate <= 0 {
+		return nil
+	}
+	burst := float64(rl.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:   rl.Rate,
+		burst:  burst,
+		factor: 1,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Refill available tokens, must be called with the lock held
+func (l *limiter) advance(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate * l.factor
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// Wait blocks until a request is allowed or the context is done
+func (l *limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.advance(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / (l.rate * l.factor) * float64(time.Second))
+	l.mu.Unlock()
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		// Return the reserved token
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Adjust the effective rate based on the status code received from the service
+func (l *limiter) observe(status int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance(time.Now())
+	switch {
+	case status == http.StatusTooManyRequests:
+		l.factor /= 2
+		if l.factor < minRateFactor {
+			l.factor = minRateFactor
+		}
+		if l.tokens > 0 {
+			l.tokens = 0
+		}
+	case status > 0 && status < 400 && l.factor < 1:
+		l.factor += rateRecoveryStep
+		if l.factor > 1 {
+			l.factor = 1
+		}
+	}
+}
+
+// Set of limiters applied to the client requests
+type rateLimiter struct {
+	global    *limiter
+	resources map[string]*limiter
+}
+
+// Return a new rate limiter for the provided configuration, 'nil' if no
+// limits are required
+func newRateLimiter(global *RateLimit, resources map[string]*RateLimit) *rateLimiter {
+	rl := &rateLimiter{
+		global:    newLimiter(global),
+		resources: make(map[string]*limiter),
+	}
+	for name, conf := range resources {
+		if l := newLimiter(conf); l != nil {
+			rl.resources[name] = l
+		}
+	}
+	if rl.global == nil && len(rl.resources) == 0 {
+		return nil
+	}
+	return rl
+}
+
+// Return the limiters applicable to the provided operation
+func (rl *rateLimiter) limiters(op string) []*limiter {
+	var list []*limiter
+	if l, ok := rl.resources[strings.SplitN(op, ".", 2)[0]]; ok {
+		list = append(list, l)
+	}
+	if rl.global != nil {
+		list = append(list, rl.global)
+	}
+	return list
+}
+
+// Wait until the operation is allowed by all applicable limiters
+func (rl *rateLimiter) Wait(ctx context.Context, op string) error {
+	for _, l := range rl.limiters(op) {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Report the status code received for the operation
+func (rl *rateLimiter) observe(op string, status int) {
+	for _, l := range rl.limiters(op) {
+		l.observe(status)
+	}
+}
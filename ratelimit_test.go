@@ -0,0 +1,65 @@
+package conekta
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		if newRateLimiter(nil, map[string]*RateLimit{"orders": {}}) != nil {
+			t.Error("limiter should be disabled")
+		}
+	})
+
+	t.Run("Burst", func(t *testing.T) {
+		rl := newRateLimiter(&RateLimit{Rate: 1, Burst: 3}, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		for i := 0; i < 3; i++ {
+			if err := rl.Wait(ctx, "orders.create"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rl.Wait(ctx, "orders.create"); err == nil {
+			t.Error("failed to limit requests over burst")
+		}
+	})
+
+	t.Run("Resource", func(t *testing.T) {
+		rl := newRateLimiter(nil, map[string]*RateLimit{"plans": {Rate: 1, Burst: 1}})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		for i := 0; i < 5; i++ {
+			if err := rl.Wait(ctx, "orders.create"); err != nil {
+				t.Fatal("unexpected limit on unrelated resource")
+			}
+		}
+		rl.Wait(ctx, "plans.create")
+		if err := rl.Wait(ctx, "plans.update"); err == nil {
+			t.Error("failed to limit resource requests")
+		}
+	})
+
+	t.Run("Adaptive", func(t *testing.T) {
+		l := newLimiter(&RateLimit{Rate: 10, Burst: 1})
+		l.observe(http.StatusTooManyRequests)
+		if l.factor != 0.5 {
+			t.Errorf("unexpected rate factor: %v", l.factor)
+		}
+		for i := 0; i < 10; i++ {
+			l.observe(http.StatusTooManyRequests)
+		}
+		if l.factor != minRateFactor {
+			t.Errorf("unexpected rate factor: %v", l.factor)
+		}
+		for i := 0; i < 100; i++ {
+			l.observe(http.StatusOK)
+		}
+		if l.factor != 1 {
+			t.Errorf("rate was not restored: %v", l.factor)
+		}
+	})
+}
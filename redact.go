@@ -0,0 +1,180 @@
+package conekta
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/fairbank-io/conekta/card"
+)
+
+// Redacted returns a copy of the card safe to be logged, with the card number
+// masked and the token ID removed
+func (c Card) Redacted() Card {
+	c.Number = card.Mask(c.Number)
+	c.TokenID = redact(c.TokenID)
+	return c
+}
+
+// Redacted returns a copy of the address safe to be logged, only the city,
+// state and country are preserved
+func (a Address) Redacted() Address {
+	a.Street1 = redact(a.Street1)
+	a.Street2 = redact(a.Street2)
+	a.PostalCode = redact(a.PostalCode)
+	return a
+}
+
+// Redacted returns a copy of the shipping contact safe to be logged, with the
+// phone number masked and the address removed
+func (s ShippingContact) Redacted() ShippingContact {
+	s.Phone = maskPhone(s.Phone)
+	s.BetweenStreets = redact(s.BetweenStreets)
+	s.Address = s.Address.Redacted()
+	return s
+}
+
+// Redacted returns a copy of the customer information safe to be logged, with
+// the email and phone number masked
+func (c CustomerInfo) Redacted() CustomerInfo {
+	c.Email = maskEmail(c.Email)
+	c.Phone = maskPhone(c.Phone)
+	return c
+}
+
+// Redacted returns a copy of the fiscal entity safe to be logged, with the
+// contact details masked and the address removed
+func (f FiscalEntity) Redacted() FiscalEntity {
+	f.Email = maskEmail(f.Email)
+	f.Phone = maskPhone(f.Phone)
+	f.Address = f.Address.Redacted()
+	return f
+}
+
+// Redacted returns a copy of the payment source update safe to be logged, with
+// the cardholder address removed
+func (p PaymentSourceUpdate) Redacted() PaymentSourceUpdate {
+	p.Address = p.Address.Redacted()
+	return p
+}
+
+// Redacted returns a copy of the customer safe to be logged, with the contact
+// details, shipping contacts and fiscal entities masked
+func (c Customer) Redacted() Customer {
+	c.Email = maskEmail(c.Email)
+	c.Phone = maskPhone(c.Phone)
+	if c.ShippingContacts != nil {
+		contacts := make([]ShippingContact, len(c.ShippingContacts))
+		for i, sc := range c.ShippingContacts {
+			contacts[i] = sc.Redacted()
+		}
+		c.ShippingContacts = contacts
+	}
+	if c.FiscalEntities != nil {
+		entities := make([]FiscalEntity, len(c.FiscalEntities))
+		for i, fe := range c.FiscalEntities {
+			entities[i] = fe.Redacted()
+		}
+		c.FiscalEntities = entities
+	}
+	return c
+}
+
+// Redacted returns a copy of the order safe to be logged, with the customer
+// information, shipping contact, fiscal entity and charges payment methods
+// masked
+func (o Order) Redacted() Order {
+	o.CustomerInfo = o.CustomerInfo.Redacted()
+	o.ShippingContact = o.ShippingContact.Redacted()
+	if o.FiscalEntity != nil {
+		fe := o.FiscalEntity.Redacted()
+		o.FiscalEntity = &fe
+	}
+	if o.Charges != nil {
+		charges := make([]Charge, len(o.Charges))
+		for i, ch := range o.Charges {
+			ch.PaymentMethod = ch.PaymentMethod.Redacted()
+			charges[i] = ch
+		}
+		o.Charges = charges
+	}
+	return o
+}
+
+// Types without methods, used to log redacted copies without resolving
+// their 'LogValue' implementation again
+type (
+	logCard                Card
+	logShippingContact     ShippingContact
+	logCustomer            Customer
+	logPaymentSourceUpdate PaymentSourceUpdate
+	logOrder               Order
+)
+
+// LogValue implements 'slog.LogValuer', logging a redacted copy of the card
+func (c Card) LogValue() slog.Value {
+	return slog.AnyValue(logCard(c.Redacted()))
+}
+
+// LogValue implements 'slog.LogValuer', logging a redacted copy of the
+// shipping contact
+func (s ShippingContact) LogValue() slog.Value {
+	return slog.AnyValue(logShippingContact(s.Redacted()))
+}
+
+// LogValue implements 'slog.LogValuer', logging a redacted copy of the customer
+func (c Customer) LogValue() slog.Value {
+	return slog.AnyValue(logCustomer(c.Redacted()))
+}
+
+// LogValue implements 'slog.LogValuer', logging a redacted copy of the
+// payment source update
+func (p PaymentSourceUpdate) LogValue() slog.Value {
+	return slog.AnyValue(logPaymentSourceUpdate(p.Redacted()))
+}
+
+// LogValue implements 'slog.LogValuer', logging a redacted copy of the order
+func (o Order) LogValue() slog.Value {
+	return slog.AnyValue(logOrder(o.Redacted()))
+}
+
+// LogValue implements 'slog.LogValuer'. Only the error type, log ID and the
+// details codes and parameters are logged, messages are omitted since they
+// may include the values submitted
+func (e *APIError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("type", e.Type),
+		slog.String("log_id", e.LogID),
+	}
+	for _, d := range e.Details {
+		attrs = append(attrs, slog.Group("detail",
+			slog.String("code", d.Code),
+			slog.String("params", d.Params)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Replace a non-empty value
+func redact(v string) string {
+	if v == "" {
+		return v
+	}
+	return redactedValue
+}
+
+// Mask an email address, keeping only the first character of the local part
+// and the domain
+func maskEmail(v string) string {
+	at := strings.LastIndex(v, "@")
+	if at < 1 {
+		return redact(v)
+	}
+	return v[:1] + "***" + v[at:]
+}
+
+// Mask a phone number, keeping only its last 4 digits
+func maskPhone(v string) string {
+	if len(v) <= 4 {
+		return strings.Repeat("*", len(v))
+	}
+	return strings.Repeat("*", len(v)-4) + v[len(v)-4:]
+}
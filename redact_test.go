@@ -0,0 +1,62 @@
+package conekta
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	order := &Order{
+		CustomerInfo: CustomerInfo{
+			Name:  "Rick Sanchez",
+			Email: "rick@citadel.com",
+			Phone: "+525511223344",
+		},
+		ShippingContact: ShippingContact{
+			Phone:   "+525511223344",
+			Address: Address{Street1: "calle 6 910", PostalCode: "94510", City: "Cordoba"},
+		},
+		Charges: []Charge{{PaymentMethod: Card{Number: "4242424242424242", Name: "Rick Sanchez"}}},
+	}
+
+	t.Run("Copy", func(t *testing.T) {
+		r := order.Redacted()
+		if r.Charges[0].PaymentMethod.Number != "424242******4242" {
+			t.Error("failed to mask card number")
+		}
+		if r.CustomerInfo.Email != "r***@citadel.com" || r.CustomerInfo.Phone != "*********3344" {
+			t.Error("failed to mask customer contact details")
+		}
+		if r.ShippingContact.Address.Street1 != redactedValue || r.ShippingContact.Address.City != "Cordoba" {
+			t.Error("failed to redact address")
+		}
+		if order.Charges[0].PaymentMethod.Number != "4242424242424242" || order.CustomerInfo.Email != "rick@citadel.com" {
+			t.Error("original value modified")
+		}
+	})
+
+	t.Run("LogValue", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, nil))
+		logger.Info("order", "order", order, "customer", Customer{Email: "rick@citadel.com"})
+		for _, v := range []string{"4242424242424242", "rick@citadel.com", "calle 6 910", "94510"} {
+			if strings.Contains(buf.String(), v) {
+				t.Errorf("sensitive value logged: %s", v)
+			}
+		}
+		if !strings.Contains(buf.String(), "Rick Sanchez") {
+			t.Error("non sensitive values should be logged")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		body := string(redactJSON([]byte(`{"card":{"number":"4242424242424242","cvc":"123"},"email":"rick@citadel.com","amount":100}`)))
+		for _, v := range []string{"424242******4242", `"cvc":"[REDACTED]"`, "r***@citadel.com", `"amount":100`} {
+			if !strings.Contains(body, v) {
+				t.Errorf("expected '%s' on redacted payload: %s", v, body)
+			}
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package conekta
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RefundableAmount returns the amount of the order, in cents, that can still
+// be refunded
+func (o *Order) RefundableAmount() uint32 {
+	if o.AmountRefunded >= o.Amount {
+		return 0
+	}
+	return o.Amount - o.AmountRefunded
+}
+
+// ValidateRefund verifies the refund can be applied to the order, rejecting
+// refunds exceeding the amount still refundable. If the refund targets a
+// specific charge, the charge must belong to the order and the amount must
+// not exceed what remains refundable on it. Refunds without amount request
+// the full remaining amount
+func (o *Order) ValidateRefund(r *Refund) error {
+	switch o.PaymentStatus {
+	case OrderPaid, OrderPartiallyRefunded, "":
+	default:
+		return errors.New("order can't be refunded, payment status: " + o.PaymentStatus)
+	}
+	available := o.RefundableAmount()
+	if r.ChargeID != "" {
+		charge := o.charge(r.ChargeID)
+		if charge == nil {
+			return errors.New("charge not found in order: " + r.ChargeID)
+		}
+		if refunded := charge.Refunds.Amount(); refunded < charge.Amount {
+			available = min(available, charge.Amount-refunded)
+		} else {
+			available = 0
+		}
+	}
+	if available == 0 {
+		return errors.New("nothing left to refund")
+	}
+	if r.Amount > available {
+		return fmt.Errorf("refund amount %d exceeds refundable amount %d", r.Amount, available)
+	}
+	return nil
+}
+
+// Return the order charge with the provided ID, if any
+func (o *Order) charge(chargeID string) *Charge {
+	for i := range o.Charges {
+		if o.Charges[i].ID == chargeID {
+			return &o.Charges[i]
+		}
+	}
+	return nil
+}
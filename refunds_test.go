@@ -0,0 +1,86 @@
+package conekta
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderRefunds(t *testing.T) {
+	order := &Order{
+		Amount:         10000,
+		AmountRefunded: 2500,
+		PaymentStatus:  OrderPartiallyRefunded,
+		Charges: []Charge{
+			{ID: "ch_1", Amount: 4000, Refunds: RefundList{{Amount: 2500}}},
+			{ID: "ch_2", Amount: 6000},
+		},
+	}
+
+	t.Run("Refundable", func(t *testing.T) {
+		if order.RefundableAmount() != 7500 {
+			t.Errorf("invalid refundable amount: %d", order.RefundableAmount())
+		}
+		if order.ValidateRefund(&Refund{Amount: 7500}) != nil {
+			t.Error("valid refund rejected")
+		}
+		if order.ValidateRefund(&Refund{Amount: 7501}) == nil {
+			t.Error("failed to detect over-refund")
+		}
+	})
+
+	t.Run("Charge", func(t *testing.T) {
+		if order.ValidateRefund(&Refund{ChargeID: "ch_1", Amount: 1500}) != nil {
+			t.Error("valid charge refund rejected")
+		}
+		if order.ValidateRefund(&Refund{ChargeID: "ch_1", Amount: 1501}) == nil {
+			t.Error("failed to detect charge over-refund")
+		}
+		if order.ValidateRefund(&Refund{ChargeID: "ch_3"}) == nil {
+			t.Error("failed to detect unknown charge")
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		voided := &Order{Amount: 100, PaymentStatus: OrderVoided}
+		if voided.ValidateRefund(&Refund{}) == nil {
+			t.Error("voided order should not be refundable")
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		charge := &Charge{}
+		data := `{"refunds": {"object": "list", "has_more": false, "data": [{"id": "ref_1", "amount": 300}]}}`
+		if err := json.Unmarshal([]byte(data), charge); err != nil {
+			t.Fatal(err)
+		}
+		if len(charge.Refunds) != 1 || charge.Refunds.Amount() != 300 {
+			t.Error("failed to decode charge refunds")
+		}
+
+		data = `{"refunds": {"object": "list", "data": [{"id": "ref_2", "amount": -500}]}}`
+		if err := json.Unmarshal([]byte(data), charge); err != nil {
+			t.Fatal(err)
+		}
+		if charge.Refunds.Amount() != 500 {
+			t.Error("failed to decode negative refund amount")
+		}
+	})
+
+	t.Run("DecodeCharges", func(t *testing.T) {
+		o := &Order{}
+		data := `{"amount": 1000, "payment_status": "paid", "charges": {"object": "list", "has_more": false,
+			"data": [{"id": "ch_1", "amount": 1000, "refunds": {"object": "list", "data": [{"amount": -400}]}}]}}`
+		if err := json.Unmarshal([]byte(data), o); err != nil {
+			t.Fatal(err)
+		}
+		if len(o.Charges) != 1 || o.Charges[0].ID != "ch_1" {
+			t.Fatal("failed to decode order charges")
+		}
+		if o.ValidateRefund(&Refund{ChargeID: "ch_1", Amount: 600}) != nil {
+			t.Error("valid charge refund rejected")
+		}
+		if o.ValidateRefund(&Refund{ChargeID: "ch_1", Amount: 601}) == nil {
+			t.Error("failed to detect charge over-refund")
+		}
+	})
+}
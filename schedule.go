@@ -0,0 +1,214 @@
+package conekta
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Plan billing intervals supported by the service
+const (
+	IntervalWeek      = "week"
+	IntervalHalfMonth = "half_month"
+	IntervalMonth     = "month"
+	IntervalYear      = "year"
+)
+
+// Subscription status values reported by the service
+const (
+	SubscriptionInTrial  = "in_trial"
+	SubscriptionActive   = "active"
+	SubscriptionPastDue  = "past_due"
+	SubscriptionPaused   = "paused"
+	SubscriptionCanceled = "canceled"
+)
+
+// A projected subscription charge
+type BillingEvent struct {
+	// Date of the charge
+	Date time.Time
+
+	// Charge's amount in cents
+	Amount uint32
+
+	// Currency of the charge
+	Currency string
+
+	// Id of the plan used to calculate the charge
+	PlanID string
+
+	// Position of the charge in the subscription lifetime, starting at 1
+	Number uint32
+}
+
+// A period of time during which a subscription is paused. Billing is resumed
+// on the 'End' date, with later charges anchored to it. A zero 'End' date
+// means the subscription is paused indefinitely
+type BillingPause struct {
+	Start time.Time
+	End   time.Time
+}
+
+// A plan change made effective at a given date. The first charge on or after
+// the date uses the new plan, and later charges follow the new plan interval
+type BillingPlanChange struct {
+	At   time.Time
+	Plan *Plan
+}
+
+// BillingSchedule projects the future charges of a subscription locally, without
+// contacting the service
+type BillingSchedule struct {
+	// Plan currently assigned to the subscription
+	Plan *Plan
+
+	// Subscription to project, its trial and billing cycle dates are used to
+	// determine the next charge
+	Subscription *Subscription
+
+	// Number of charges already made, used to honor the plan's expiry count
+	ChargesMade uint32
+
+	// Expected resume date for a paused subscription. Paused subscriptions
+	// without a resume date have no future charges
+	ResumeAt time.Time
+
+	// Planned pauses
+	Pauses []BillingPause
+
+	// Planned plan changes
+	PlanChanges []BillingPlanChange
+}
+
+// Project returns up to 'count' future charges for the subscription
+func (bs *BillingSchedule) Project(count int) ([]BillingEvent, error) {
+	if bs.Plan == nil || bs.Subscription == nil {
+		return nil, errors.New("plan and subscription are required")
+	}
+	if err := validateInterval(bs.Plan); err != nil {
+		return nil, err
+	}
+	for _, pc := range bs.PlanChanges {
+		if pc.Plan == nil {
+			return nil, errors.New("plan change without plan")
+		}
+		if err := validateInterval(pc.Plan); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := bs.Subscription
+	if sub.Status == SubscriptionCanceled {
+		return nil, nil
+	}
+
+	// Collect pauses, including the current one for paused subscriptions
+	pauses := append([]BillingPause{}, bs.Pauses...)
+	if sub.Status == SubscriptionPaused {
+		pauses = append(pauses, BillingPause{Start: unixTime(sub.PausedAt), End: bs.ResumeAt})
+	}
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Start.Before(pauses[j].Start) })
+	changes := append([]BillingPlanChange{}, bs.PlanChanges...)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].At.Before(changes[j].At) })
+
+	plan := bs.Plan
+	anchor := nextChargeDate(plan, sub)
+	var events []BillingEvent
+	for k, made := 0, bs.ChargesMade; len(events) < count; k++ {
+		if plan.ExpiryCount > 0 && made >= plan.ExpiryCount {
+			break
+		}
+		date := addInterval(anchor, plan, k)
+
+		// Delay charges falling inside a pause until billing is resumed
+		for _, p := range pauses {
+			if date.Before(p.Start) || (!p.End.IsZero() && !date.Before(p.End)) {
+				continue
+			}
+			if p.End.IsZero() {
+				return events, nil
+			}
+			date = p.End
+			anchor, k = date, 0
+		}
+
+		// Apply the latest plan change effective at the charge date
+		for len(changes) > 0 && !date.Before(changes[0].At) {
+			if changes[0].Plan != plan {
+				plan = changes[0].Plan
+				anchor, k = date, 0
+			}
+			changes = changes[1:]
+		}
+		if plan.ExpiryCount > 0 && made >= plan.ExpiryCount {
+			break
+		}
+
+		made++
+		events = append(events, BillingEvent{
+			Date:     date,
+			Amount:   plan.Amount,
+			Currency: plan.Currency,
+			PlanID:   plan.ID,
+			Number:   made,
+		})
+	}
+	return events, nil
+}
+
+// Return the date of the next charge for the subscription
+func nextChargeDate(plan *Plan, sub *Subscription) time.Time {
+	switch {
+	case sub.Status == SubscriptionInTrial && sub.TrialEnd > 0:
+		return unixTime(sub.TrialEnd)
+	case sub.BillingCycleEnd > 0:
+		return unixTime(sub.BillingCycleEnd)
+	case sub.TrialEnd > 0:
+		return unixTime(sub.TrialEnd)
+	}
+	return unixTime(sub.CreatedAt).AddDate(0, 0, int(plan.TrialPeriodDays))
+}
+
+// Verify the plan billing interval is supported
+func validateInterval(plan *Plan) error {
+	switch plan.Interval {
+	case IntervalWeek, IntervalHalfMonth, IntervalMonth, IntervalYear:
+		return nil
+	}
+	return errors.New("unsupported plan interval: " + plan.Interval)
+}
+
+// Return the date 'n' billing periods after 'anchor'. Month based intervals
+// are clamped to the last day of the month, so a plan anchored on the 31st
+// is billed on the 30th or 28th on shorter months
+func addInterval(anchor time.Time, plan *Plan, n int) time.Time {
+	freq := int(plan.Frequency)
+	if freq == 0 {
+		freq = 1
+	}
+	n *= freq
+	switch plan.Interval {
+	case IntervalWeek:
+		return anchor.AddDate(0, 0, 7*n)
+	case IntervalHalfMonth:
+		return addMonths(anchor, n/2).AddDate(0, 0, 15*(n%2))
+	case IntervalYear:
+		return addMonths(anchor, 12*n)
+	}
+	return addMonths(anchor, n)
+}
+
+// Add calendar months to a date, clamping to the end of the month
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// Convert a service timestamp to a UTC time value
+func unixTime(ts uint32) time.Time {
+	return time.Unix(int64(ts), 0).UTC()
+}
@@ -0,0 +1,110 @@
+package conekta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBillingSchedule(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	plan := &Plan{
+		ID:          "monthly",
+		Amount:      5000,
+		Currency:    "MXN",
+		Interval:    IntervalMonth,
+		Frequency:   1,
+		ExpiryCount: 6,
+	}
+
+	t.Run("Monthly", func(t *testing.T) {
+		bs := &BillingSchedule{
+			Plan: plan,
+			Subscription: &Subscription{
+				Status:          SubscriptionActive,
+				BillingCycleEnd: uint32(day(2024, time.January, 31).Unix()),
+			},
+			ChargesMade: 1,
+		}
+		events, err := bs.Project(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 5 {
+			t.Fatalf("expiry count not honored: %d charges", len(events))
+		}
+		expected := []time.Time{
+			day(2024, time.January, 31),
+			day(2024, time.February, 29),
+			day(2024, time.March, 31),
+			day(2024, time.April, 30),
+		}
+		for i, d := range expected {
+			if !events[i].Date.Equal(d) {
+				t.Errorf("unexpected charge date: %s != %s", events[i].Date, d)
+			}
+		}
+		if events[0].Number != 2 || events[0].Amount != 5000 {
+			t.Error("invalid charge details")
+		}
+	})
+
+	t.Run("Trial", func(t *testing.T) {
+		bs := &BillingSchedule{
+			Plan: &Plan{Amount: 100, Interval: IntervalWeek, Frequency: 2, TrialPeriodDays: 10},
+			Subscription: &Subscription{
+				Status:    SubscriptionActive,
+				CreatedAt: uint32(day(2024, time.March, 1).Unix()),
+			},
+		}
+		events, _ := bs.Project(2)
+		if !events[0].Date.Equal(day(2024, time.March, 11)) || !events[1].Date.Equal(day(2024, time.March, 25)) {
+			t.Error("invalid trial handling")
+		}
+	})
+
+	t.Run("Pause", func(t *testing.T) {
+		bs := &BillingSchedule{
+			Plan: plan,
+			Subscription: &Subscription{
+				Status:          SubscriptionActive,
+				BillingCycleEnd: uint32(day(2024, time.January, 10).Unix()),
+			},
+			Pauses: []BillingPause{{Start: day(2024, time.February, 1), End: day(2024, time.March, 20)}},
+		}
+		events, _ := bs.Project(3)
+		if !events[1].Date.Equal(day(2024, time.March, 20)) || !events[2].Date.Equal(day(2024, time.April, 20)) {
+			t.Error("invalid pause handling")
+		}
+
+		bs.Subscription.Status = SubscriptionPaused
+		bs.Subscription.PausedAt = uint32(day(2024, time.January, 5).Unix())
+		if events, _ := bs.Project(3); len(events) != 0 {
+			t.Error("paused subscription without resume date should not be charged")
+		}
+	})
+
+	t.Run("PlanChange", func(t *testing.T) {
+		yearly := &Plan{ID: "yearly", Amount: 50000, Interval: IntervalYear}
+		bs := &BillingSchedule{
+			Plan: plan,
+			Subscription: &Subscription{
+				Status:          SubscriptionActive,
+				BillingCycleEnd: uint32(day(2024, time.January, 10).Unix()),
+			},
+			PlanChanges: []BillingPlanChange{{At: day(2024, time.January, 20), Plan: yearly}},
+		}
+		events, _ := bs.Project(3)
+		if events[1].PlanID != "yearly" || !events[2].Date.Equal(day(2025, time.February, 10)) {
+			t.Error("invalid plan change handling")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		bs := &BillingSchedule{Plan: plan, Subscription: &Subscription{Status: SubscriptionCanceled}}
+		if events, _ := bs.Project(3); len(events) != 0 {
+			t.Error("canceled subscription should not be charged")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package conekta
+
+import (
+	"net/http"
+	"path"
+)
+
+// Defines the public interface required to access available 'subscriptions' methods
+type SubscriptionsAPI interface {
+	// Creates a new subscription for an existing customer
+	// https://developers.conekta.com/api?language=bash#create-subscription
+	Create(customerID string, params *SubscriptionParams) (*Subscription, error)
+
+	// Retrieves a customer subscription. If no subscription ID is provided the
+	// customer's main subscription is returned
+	// https://developers.conekta.com/api?language=bash#subscription
+	Get(customerID, subscriptionID string) (*Subscription, error)
+
+	// Lists all the subscriptions of a customer
+	// https://developers.conekta.com/api?language=bash#subscription
+	List(customerID string) ([]Subscription, error)
+
+	// Updates a subscription with a different card, plan or trial end
+	// https://developers.conekta.com/api?language=bash#update-subscription
+	Update(customerID string, params *SubscriptionParams) (*Subscription, error)
+
+	// Pauses a subscription. If no subscription ID is provided the customer's
+	// main subscription is paused. See 'Options.MultipleSubscriptions' for the
+	// routes used
+	// https://developers.conekta.com/api?language=bash#pause-subscription
+	Pause(customerID, subscriptionID string) (*Subscription, error)
+
+	// Resume a subscription. If no subscription ID is provided the customer's
+	// main subscription is resumed
+	// https://developers.conekta.com/api?language=bash#resume-subscription
+	Resume(customerID, subscriptionID string) (*Subscription, error)
+
+	// Cancel a subscription. If no subscription ID is provided the customer's
+	// main subscription is canceled
+	// https://developers.conekta.com/api?language=bash#cancel-subscription
+	Cancel(customerID, subscriptionID string) (*Subscription, error)
+}
+
+type subscriptionsClient struct {
+	c *Client
+}
+
+// Return the endpoint for a customer subscription. Customers with multiple
+// subscriptions require the subscription ID to be provided
+func subscriptionEndpoint(customerID, subscriptionID string, action ...string) string {
+	p := path.Join("customers", customerID, "subscription")
+	if subscriptionID != "" {
+		p = path.Join("customers", customerID, "subscriptions", subscriptionID)
+	}
+	return baseUrl + path.Join(append([]string{p}, action...)...)
+}
+
+func (sc *subscriptionsClient) Create(customerID string, params *SubscriptionParams) (*Subscription, error) {
+	return send[Subscription](sc.c, "subscriptions.create", http.MethodPost, subscriptionEndpoint(customerID, ""), params)
+}
+
+func (sc *subscriptionsClient) Get(customerID, subscriptionID string) (*Subscription, error) {
+	return send[Subscription](sc.c, "subscriptions.get", http.MethodGet, subscriptionEndpoint(customerID, subscriptionID), nil)
+}
+
+func (sc *subscriptionsClient) List(customerID string) ([]Subscription, error) {
+	return listAll[Subscription](sc.c, "subscriptions.list", baseUrl+path.Join("customers", customerID, "subscriptions"), nil)
+}
+
+func (sc *subscriptionsClient) Update(customerID string, params *SubscriptionParams) (*Subscription, error) {
+	return send[Subscription](sc.c, "subscriptions.update", http.MethodPut, subscriptionEndpoint(customerID, params.ID), params)
+}
+
+func (sc *subscriptionsClient) Pause(customerID, subscriptionID string) (*Subscription, error) {
+	return sc.action("subscriptions.pause", customerID, subscriptionID, "pause")
+}
+
+func (sc *subscriptionsClient) Resume(customerID, subscriptionID string) (*Subscription, error) {
+	return sc.action("subscriptions.resume", customerID, subscriptionID, "resume")
+}
+
+func (sc *subscriptionsClient) Cancel(customerID, subscriptionID string) (*Subscription, error) {
+	return sc.action("subscriptions.cancel", customerID, subscriptionID, "cancel")
+}
+
+// Dispatch an action on a customer subscription. The subscription is provided
+// on the request body using the single subscription routes, unless multiple
+// subscriptions support is enabled on the client options
+func (sc *subscriptionsClient) action(op, customerID, subscriptionID, action string) (*Subscription, error) {
+	if sc.c.multipleSubscriptions && subscriptionID != "" {
+		return send[Subscription](sc.c, op, http.MethodPost, subscriptionEndpoint(customerID, subscriptionID, action), nil)
+	}
+	return legacySubscriptionAction(sc.c, op, customerID, subscriptionID, action)
+}
+
+// Dispatch an action on a customer subscription using the single subscription
+// routes, providing the subscription ID on the request body
+func legacySubscriptionAction(c *Client, op, customerID, subscriptionID, action string) (*Subscription, error) {
+	return send[Subscription](c, op, http.MethodPost, subscriptionEndpoint(customerID, "", action),
+		map[string]string{"id": subscriptionID})
+}
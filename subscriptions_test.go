@@ -0,0 +1,60 @@
+package conekta
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSubscriptions(t *testing.T) {
+	rt := &recordingTransport{reply: `{"id": "sub_1", "status": "paused", "customer_id": "cus_1"}`}
+	client := recordingClient(t, rt)
+
+	t.Run("Decode", func(t *testing.T) {
+		sub, err := client.Subscriptions.Pause("cus_1", "sub_1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.ID != "sub_1" || sub.Status != SubscriptionPaused {
+			t.Errorf("invalid subscription: %+v", sub)
+		}
+	})
+
+	t.Run("Endpoints", func(t *testing.T) {
+		multi := recordingClient(t, rt)
+		multi.multipleSubscriptions = true
+		calls := []struct {
+			call               func()
+			method, path, body string
+		}{
+			{func() { client.Subscriptions.Get("cus_1", "sub_1") }, http.MethodGet, "/customers/cus_1/subscriptions/sub_1", ""},
+			{func() { client.Subscriptions.Update("cus_1", &SubscriptionParams{ID: "sub_1", PlanID: "plan_1"}) },
+				http.MethodPut, "/customers/cus_1/subscriptions/sub_1", `{"id":"sub_1","plan":"plan_1"}`},
+			{func() { client.Subscriptions.Pause("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/pause", `{"id":"sub_1"}`},
+			{func() { client.Subscriptions.Resume("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/resume", `{"id":"sub_1"}`},
+			{func() { client.Subscriptions.Cancel("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/cancel", `{"id":"sub_1"}`},
+			{func() { multi.Subscriptions.Pause("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscriptions/sub_1/pause", ""},
+			{func() { multi.Subscriptions.Resume("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscriptions/sub_1/resume", ""},
+			{func() { multi.Subscriptions.Cancel("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscriptions/sub_1/cancel", ""},
+			{func() { multi.Subscriptions.Cancel("cus_1", "") }, http.MethodPost, "/customers/cus_1/subscription/cancel", `{"id":""}`},
+
+			// Deprecated methods always use the single subscription routes
+			{func() { multi.Customers.PauseSubscription("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/pause", `{"id":"sub_1"}`},
+			{func() { multi.Customers.ResumeSubscription("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/resume", `{"id":"sub_1"}`},
+			{func() { multi.Customers.CancelSubscription("cus_1", "sub_1") }, http.MethodPost, "/customers/cus_1/subscription/cancel", `{"id":"sub_1"}`},
+		}
+		for i, c := range calls {
+			rt.requests, rt.bodies = nil, nil
+			c.call()
+			if len(rt.requests) != 1 {
+				t.Fatalf("%d: unexpected number of requests: %d", i, len(rt.requests))
+			}
+			req := rt.requests[0]
+			if req.Method != c.method || req.URL.Path != c.path {
+				t.Errorf("%d: expected '%s %s', got '%s %s'", i, c.method, c.path, req.Method, req.URL.Path)
+			}
+			if rt.bodies[0] != c.body {
+				t.Errorf("%d: expected body '%s', got '%s'", i, c.body, rt.bodies[0])
+			}
+		}
+	})
+}
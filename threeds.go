@@ -0,0 +1,49 @@
+package conekta
+
+// AuthenticationRequired describes a 3-D Secure challenge the buyer must
+// complete before a card payment can proceed. It is returned by
+// 'Order.AuthenticationRequired' and, as an error, by 'Orders.CreateCharge' and
+// 'Orders.ResumeAuthentication'. Redirect the buyer to 'URL' and use
+// 'Orders.ResumeAuthentication' once they return
+type AuthenticationRequired struct {
+	// Order awaiting authentication
+	OrderID string
+
+	// URL the buyer must be redirected to
+	URL string
+
+	// URL the buyer returns to after completing the challenge
+	ReturnURL string
+}
+
+func (e *AuthenticationRequired) Error() string {
+	return "authentication required"
+}
+
+// AuthenticationRequired returns the pending 3-D Secure challenge of the order,
+// 'nil' if no authentication is required
+func (o *Order) AuthenticationRequired() *AuthenticationRequired {
+	na := o.NextAction
+	if na == nil {
+		for _, c := range o.Charges {
+			if c.NextAction != nil {
+				na = c.NextAction
+				break
+			}
+		}
+	}
+	return authenticationRequired(o.ID, na)
+}
+
+// Return the authentication challenge described by a next action, 'nil' if
+// it doesn't require one
+func authenticationRequired(orderID string, na *NextAction) *AuthenticationRequired {
+	if na == nil || na.Type != NextActionRedirectToURL || na.RedirectToURL == nil {
+		return nil
+	}
+	return &AuthenticationRequired{
+		OrderID:   orderID,
+		URL:       na.RedirectToURL.URL,
+		ReturnURL: na.RedirectToURL.ReturnURL,
+	}
+}
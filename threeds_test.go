@@ -0,0 +1,67 @@
+package conekta
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAuthenticationRequired(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		o := &Order{}
+		data := `{"id": "ord_1", "next_action": {"type": "redirect_to_url",
+			"redirect_to_url": {"url": "https://3ds.example.com", "return_url": "https://shop.example.com"}}}`
+		if err := json.Unmarshal([]byte(data), o); err != nil {
+			t.Fatal(err)
+		}
+		ar := o.AuthenticationRequired()
+		if ar == nil {
+			t.Fatal("failed to detect order authentication")
+		}
+		if ar.OrderID != "ord_1" || ar.URL != "https://3ds.example.com" || ar.ReturnURL != "https://shop.example.com" {
+			t.Error("invalid authentication details")
+		}
+	})
+
+	t.Run("Charge", func(t *testing.T) {
+		o := &Order{}
+		data := `{"id": "ord_2", "charges": {"object": "list", "data": [{"id": "ch_1",
+			"next_action": {"type": "redirect_to_url", "redirect_to_url": {"url": "https://3ds.example.com"}}}]}}`
+		if err := json.Unmarshal([]byte(data), o); err != nil {
+			t.Fatal(err)
+		}
+		ar := o.AuthenticationRequired()
+		if ar == nil || ar.OrderID != "ord_2" || ar.URL != "https://3ds.example.com" {
+			t.Error("failed to detect charge authentication")
+		}
+	})
+
+	t.Run("NotRequired", func(t *testing.T) {
+		o := &Order{ID: "ord_3"}
+		if o.AuthenticationRequired() != nil {
+			t.Error("order without next action should not require authentication")
+		}
+		o.NextAction = &NextAction{Type: "display_details"}
+		if o.AuthenticationRequired() != nil {
+			t.Error("unsupported next action should not require authentication")
+		}
+		if authenticationRequired("ord_3", &NextAction{Type: NextActionRedirectToURL}) != nil {
+			t.Error("redirect without URL should not require authentication")
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		rt := &recordingTransport{reply: `{"id": "ord_4", "next_action": {"type": "redirect_to_url",
+			"redirect_to_url": {"url": "https://3ds.example.com"}}}`}
+		client := recordingClient(t, rt)
+		order := &Order{Currency: "MXN"}
+		if err := client.Orders.Create(order); err != nil {
+			t.Fatal("order creation should succeed when authentication is pending")
+		}
+		if ar := order.AuthenticationRequired(); ar == nil || ar.OrderID != "ord_4" {
+			t.Error("failed to detect pending authentication on created order")
+		}
+		if _, err := client.Orders.ResumeAuthentication("ord_4"); err == nil {
+			t.Error("pending authentication should be reported when resuming")
+		}
+	})
+}
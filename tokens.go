@@ -0,0 +1,71 @@
+package conekta
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Defines the public interface required to access available 'tokens' methods.
+// Tokens are created using the public API key provided on the client options
+type TokensAPI interface {
+	// Creates a new card token
+	// https://developers.conekta.com/api?language=bash#create-token
+	Create(card *Card, cvc string) (*Token, error)
+
+	// Tokenize creates a token for the card and replaces its sensitive details
+	// with the token ID, so it can be safely attached to a charge or used to
+	// create a payment source
+	Tokenize(card *Card, cvc string) error
+}
+
+type tokensClient struct {
+	c *Client
+}
+
+func (tc *tokensClient) Create(card *Card, cvc string) (*Token, error) {
+	if tc.c.publicKey == "" {
+		return nil, errors.New("public API key is required to create tokens")
+	}
+	if card.Number == "" {
+		return nil, errors.New("card number is required")
+	}
+	if err := card.Validate(); err != nil {
+		return nil, err
+	}
+	data := map[string]map[string]string{
+		"card": {
+			"number":    card.Number,
+			"name":      card.Name,
+			"exp_month": card.ExpMonth,
+			"exp_year":  card.ExpYear,
+			"cvc":       cvc,
+		},
+	}
+	b, err := tc.c.request(&requestOptions{
+		op:       "tokens.create",
+		endpoint: baseUrl + "tokens",
+		method:   http.MethodPost,
+		data:     data,
+		public:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	token := &Token{}
+	json.Unmarshal(b, token)
+	return token, nil
+}
+
+func (tc *tokensClient) Tokenize(card *Card, cvc string) error {
+	token, err := tc.Create(card, cvc)
+	if err != nil {
+		return err
+	}
+	*card = Card{
+		Type:    PaymentSourceCard,
+		Name:    card.Name,
+		TokenID: token.ID,
+	}
+	return nil
+}
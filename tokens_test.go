@@ -0,0 +1,45 @@
+package conekta
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Card expiration year that remains valid when the tests run
+var testExpYear = strconv.Itoa(time.Now().AddDate(5, 0, 0).Year())
+
+func TestTokens(t *testing.T) {
+	rt := &recordingTransport{reply: `{"id": "tok_1", "object": "token", "used": false}`}
+	client, err := NewClient("key_test", &Options{PublicKey: "key_public", APIVersion: "v2.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+
+	t.Run("Tokenize", func(t *testing.T) {
+		card := &Card{Number: "4242424242424242", Name: "Rick Sanchez", ExpMonth: "12", ExpYear: testExpYear}
+		if err := client.Tokens.Tokenize(card, "123"); err != nil {
+			t.Fatal(err)
+		}
+		if card.Number != "" || card.TokenID != "tok_1" {
+			t.Errorf("card details should be replaced by the token: %+v", card)
+		}
+		if user, _, _ := rt.requests[0].BasicAuth(); user != "key_public" {
+			t.Error("tokens must be created with the public key")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		rt.requests = nil
+		if _, err := client.Tokens.Create(&Card{TokenID: "tok_1"}, "123"); err == nil {
+			t.Error("failed to detect missing card number")
+		}
+		if _, err := client.Tokens.Create(&Card{Number: "4242424242424241", ExpMonth: "12", ExpYear: testExpYear}, "123"); err == nil {
+			t.Error("failed to detect invalid card number")
+		}
+		if len(rt.requests) != 0 {
+			t.Error("invalid cards should not be sent")
+		}
+	})
+}
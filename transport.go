@@ -0,0 +1,158 @@
+package conekta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Host name of the service, certificate pins are only enforced for it
+const apiHost = "api.conekta.io"
+
+// Network transport configuration, sane default values are used for any
+// setting not provided. All timeouts are expressed in seconds
+type TransportOptions struct {
+	// Proxy server to use, for example 'http://proxy.local:3128'. If not provided
+	// the proxy is taken from the environment: HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	Proxy string
+
+	// Minimum TLS version accepted, defaults to TLS 1.2
+	MinTLSVersion uint16
+
+	// Root certificate authorities used to verify the service certificate, if
+	// not provided the system pool is used
+	RootCAs *x509.CertPool
+
+	// Base64 encoded SHA-256 hashes of the SubjectPublicKeyInfo of trusted
+	// certificates for the service. If provided, connections to the service are
+	// rejected unless a certificate in the verified chain matches a pin
+	PinnedKeys []string
+
+	// Time to wait for a network connection to be established
+	DialTimeout uint
+
+	// Time to wait for the TLS handshake to complete
+	TLSHandshakeTimeout uint
+
+	// Time to wait for the service response headers after sending the request
+	ResponseHeaderTimeout uint
+
+	// Time an idle connection is kept in the pool before closing it
+	IdleConnTimeout uint
+
+	// Disable the use of HTTP/2
+	DisableHTTP2 bool
+}
+
+// Return sane default transport values
+func defaultTransportOptions() *TransportOptions {
+	return &TransportOptions{
+		MinTLSVersion:         tls.VersionTLS12,
+		DialTimeout:           10,
+		TLSHandshakeTimeout:   10,
+		ResponseHeaderTimeout: 30,
+		IdleConnTimeout:       90,
+	}
+}
+
+// Return a copy of the provided transport options using default values for
+// any setting not provided
+func (to *TransportOptions) withDefaults() *TransportOptions {
+	def := defaultTransportOptions()
+	if to == nil {
+		return def
+	}
+	res := *to
+	if res.MinTLSVersion == 0 {
+		res.MinTLSVersion = def.MinTLSVersion
+	}
+	if res.DialTimeout == 0 {
+		res.DialTimeout = def.DialTimeout
+	}
+	if res.TLSHandshakeTimeout == 0 {
+		res.TLSHandshakeTimeout = def.TLSHandshakeTimeout
+	}
+	if res.ResponseHeaderTimeout == 0 {
+		res.ResponseHeaderTimeout = def.ResponseHeaderTimeout
+	}
+	if res.IdleConnTimeout == 0 {
+		res.IdleConnTimeout = def.IdleConnTimeout
+	}
+	return &res
+}
+
+// Build the HTTP transport used to communicate with the service
+func newTransport(options *Options) (*http.Transport, error) {
+	to := options.Transport.withDefaults()
+
+	// Proxy settings
+	proxy := http.ProxyFromEnvironment
+	if to.Proxy != "" {
+		u, err := url.Parse(to.Proxy)
+		if err != nil || u.Host == "" {
+			return nil, errors.New("invalid proxy URL")
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	// TLS settings
+	tc := &tls.Config{
+		MinVersion: to.MinTLSVersion,
+		RootCAs:    to.RootCAs,
+	}
+	if len(to.PinnedKeys) > 0 {
+		pins := make([][]byte, len(to.PinnedKeys))
+		for i, p := range to.PinnedKeys {
+			pin, err := base64.StdEncoding.DecodeString(p)
+			if err != nil || len(pin) != sha256.Size {
+				return nil, errors.New("invalid certificate pin")
+			}
+			pins[i] = pin
+		}
+		tc.VerifyConnection = verifyPins(pins)
+	}
+
+	return &http.Transport{
+		Proxy:                 proxy,
+		TLSClientConfig:       tc,
+		ForceAttemptHTTP2:     !to.DisableHTTP2,
+		MaxIdleConns:          int(options.MaxConnections),
+		MaxIdleConnsPerHost:   int(options.MaxConnections),
+		TLSHandshakeTimeout:   time.Duration(to.TLSHandshakeTimeout) * time.Second,
+		ResponseHeaderTimeout: time.Duration(to.ResponseHeaderTimeout) * time.Second,
+		IdleConnTimeout:       time.Duration(to.IdleConnTimeout) * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		DialContext: (&net.Dialer{
+			Timeout:   time.Duration(to.DialTimeout) * time.Second,
+			KeepAlive: time.Duration(options.KeepAlive) * time.Second,
+		}).DialContext,
+	}, nil
+}
+
+// Return a connection verification function that requires a certificate in the
+// verified chain to match one of the provided public key pins
+func verifyPins(pins [][]byte) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if cs.ServerName != apiHost {
+			return nil
+		}
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				for _, pin := range pins {
+					if bytes.Equal(sum[:], pin) {
+						return nil
+					}
+				}
+			}
+		}
+		return errors.New("service certificate doesn't match any pinned key")
+	}
+}
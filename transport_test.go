@@ -0,0 +1,121 @@
+package conekta
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Records the requests dispatched by a client and replies with a fixed status,
+// 200 by default, and the configured bodies
+type recordingTransport struct {
+	requests []*http.Request
+	bodies   []string
+	status   int
+	reply    string
+
+	// Replies returned in order, 'reply' is used once exhausted
+	replies []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+	}
+	rt.requests = append(rt.requests, req)
+	rt.bodies = append(rt.bodies, body)
+	reply := rt.reply
+	if len(rt.replies) > 0 {
+		reply, rt.replies = rt.replies[0], rt.replies[1:]
+	}
+	status := rt.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewBufferString(reply)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+// Return a client dispatching its requests to the provided transport
+func recordingClient(t *testing.T, rt *recordingTransport) *Client {
+	client, err := NewClient("key_test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+	return client
+}
+
+func TestTransport(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		tr, err := newTransport(&Options{Transport: &TransportOptions{Proxy: "http://proxy.local:3128"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr.TLSHandshakeTimeout != 10*time.Second || tr.ResponseHeaderTimeout != 30*time.Second || tr.IdleConnTimeout != 90*time.Second {
+			t.Error("failed to apply default timeouts")
+		}
+		if tr.TLSClientConfig.MinVersion != tls.VersionTLS12 || !tr.ForceAttemptHTTP2 {
+			t.Error("failed to apply default TLS settings")
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		tr, err := newTransport(&Options{Transport: &TransportOptions{
+			MinTLSVersion:       tls.VersionTLS13,
+			TLSHandshakeTimeout: 5,
+			DisableHTTP2:        true,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr.TLSHandshakeTimeout != 5*time.Second || tr.IdleConnTimeout != 90*time.Second {
+			t.Error("invalid timeouts")
+		}
+		if tr.TLSClientConfig.MinVersion != tls.VersionTLS13 || tr.ForceAttemptHTTP2 {
+			t.Error("invalid TLS settings")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := newTransport(&Options{Transport: &TransportOptions{Proxy: "proxy"}}); err == nil {
+			t.Error("failed to detect invalid proxy")
+		}
+		if _, err := newTransport(&Options{Transport: &TransportOptions{PinnedKeys: []string{"invalid"}}}); err == nil {
+			t.Error("failed to detect invalid pin")
+		}
+	})
+}
+
+func TestClientOptions(t *testing.T) {
+	rt := &recordingTransport{reply: `{}`}
+	client, err := NewClient("key_test", &Options{Hooks: &Hooks{}, UserAgent: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.c.Transport = rt
+	if client.c.Timeout != 30*time.Second {
+		t.Errorf("failed to apply default timeout: %s", client.c.Timeout)
+	}
+	client.Orders.Get("ord_1")
+	if accept := rt.requests[0].Header.Get("Accept"); accept != "application/vnd.conekta-v2.0.0+json" {
+		t.Errorf("failed to apply default API version: %s", accept)
+	}
+	if ua := rt.requests[0].Header.Get("User-Agent"); ua != "test" {
+		t.Errorf("provided settings should be kept: %s", ua)
+	}
+
+	client, _ = NewClient("key_test", &Options{Timeout: 5, APIVersion: "v2.1.0"})
+	if client.c.Timeout != 5*time.Second || client.apiVersion != "v2.1.0" {
+		t.Error("provided settings should not be replaced")
+	}
+}